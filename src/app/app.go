@@ -2,7 +2,9 @@ package app
 
 import (
 	"fmt"
+	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/Kosha-Nirman/slate/src/config"
@@ -10,7 +12,12 @@ import (
 	"github.com/Kosha-Nirman/slate/src/display"
 	"github.com/Kosha-Nirman/slate/src/models"
 	"github.com/Kosha-Nirman/slate/src/navigation"
+	"github.com/Kosha-Nirman/slate/src/remote"
+	"github.com/Kosha-Nirman/slate/src/runner"
+	"github.com/Kosha-Nirman/slate/src/terminal"
 	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/Kosha-Nirman/slate/src/timer"
+	"github.com/Kosha-Nirman/slate/src/watch"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -21,52 +28,133 @@ type ViewMode int
 const (
 	ViewPresentation ViewMode = iota
 	ViewHelp
+	ViewOverview
 )
 
+const (
+	// * Longest count prefix accepted before further digits are ignored
+	maxCountDigits = 6
+
+	// * Lines below the slide used by the command footer
+	footerLines = 1
+
+	// * Lines scrolled by one step of the mouse wheel
+	wheelScrollLines = 3
+)
+
+// * Options control optional features of a presentation run
+type Options struct {
+	// Presenter serves the presentation to `slate presenter` consoles
+	Presenter bool
+	// Watch reloads the presentation when its file changes
+	Watch bool
+	// NoAnimations shows slide changes without transitions
+	NoAnimations bool
+	// AllowExec runs code blocks without asking first
+	AllowExec bool
+}
+
 // * BubbleTea model for App
 type App struct {
 	config *models.Config
+	// configTheme is the theme before the deck's front matter is applied
+	configTheme models.ThemeConfig
 
 	presentation *models.Presentation
 	viewMode     ViewMode
 
-	theme     *theme.Manager
+	theme *theme.Manager
+	// palette is the palette picked with c, if any
+	palette   string
 	renderer  *display.Renderer
 	navigator *navigation.Navigator
 
+	server    *remote.Server
+	watcher   *watch.Watcher
+	reloadErr error
+
+	// * Talk timer, and how the deck splits its time across slides
+	timer *timer.Timer
+	plan  timer.Plan
+
+	// * Code blocks run from slides, and whether the deck may run code
+	runs        map[runKey]*codeRun
+	confirming  *confirmation
+	execAllowed bool
+
+	// * Interactive terminal of a terminal slide, and whether keys go to it
+	term        *terminal.Terminal
+	termSlide   int
+	termFocused bool
+	termExited  bool
+
 	width  int
 	height int
 
+	prompt     prompt
+	promptFor  promptPurpose
+	count      string
+	notice     string
+	message    string
+	overview   overview
+	search     searchState
+	scroll     int
+	transition transition
+	// ticking is set while a clock tick is scheduled
+	ticking bool
+	// revealMatch scrolls to the search match once navigation settles
+	revealMatch bool
+
 	err   error
 	ready bool
 }
 
-func New(filePath string) (*App, error) {
+func load(filePath string) (*models.Config, *models.Presentation, error) {
 	configLoader := config.New()
 	cfg, err := configLoader.Load()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config: %w", err)
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// ? Validate file
 	if err := data.ValidateFile(filePath); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// * Parse Presentation
 	p := data.New(filePath)
 	presentation, err := p.Parse()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse presentation: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse presentation: %w", err)
 	}
 
 	// ? Validate presentation
 	if err := presentation.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid presentation: %w", err)
+		return nil, nil, fmt.Errorf("invalid presentation: %w", err)
 	}
 
 	presentation.Config = cfg
 
+	// ? Catch broken header and footer templates before the screen opens
+	if err := display.ValidateLayout(presentation.Layout()); err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, presentation, nil
+}
+
+func New(filePath string) (*App, error) {
+	cfg, presentation, err := load(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// * Keep the configured theme, so reloads put the deck's theme on top of it again
+	configTheme := cfg.Theme
+	if cfg.Theme, err = theme.DeckTheme(configTheme, presentation); err != nil {
+		return nil, err
+	}
+
 	// * Create navigator
 	nav := navigation.New(presentation)
 
@@ -75,20 +163,29 @@ func New(filePath string) (*App, error) {
 
 	return &App{
 		config:       cfg,
+		configTheme:  configTheme,
 		viewMode:     ViewPresentation,
 		theme:        themeManager,
 		navigator:    nav,
 		presentation: presentation,
+		timer:        timer.New(),
+		plan:         timer.PlanFor(presentation),
+		execAllowed:  runner.Allowed(filePath, cfg.Exec.Allow),
 	}, nil
 }
 
-func (a *App) containsKey(keys []string, key string) bool {
+func containsKey(keys []string, key string) bool {
 	return slices.Contains(keys, key)
 }
 
 func (a *App) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
+	// Keys go to the slide terminal while it has focus
+	if a.termFocused {
+		return a, a.handleTerminalKey(msg)
+	}
+
 	// Handle help view
 	if a.viewMode == ViewHelp {
 		if key == "?" || key == "q" || key == "esc" {
@@ -97,8 +194,48 @@ func (a *App) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
+	// Handle the slide overview
+	if a.viewMode == ViewOverview {
+		return a.handleOverviewKey(msg)
+	}
+
+	// Handle the jump prompt
+	if a.prompt.active {
+		return a.handlePromptKey(msg)
+	}
+
+	// Answer whether to run code from the deck
+	if a.confirming != nil {
+		return a, a.handleConfirmKey(key)
+	}
+
+	// ? Ctrl+C stops running code before it quits
+	if key == "ctrl+c" && a.cancelRuns() {
+		return a, nil
+	}
+
+	a.notice = ""
+	a.message = ""
+
+	// Collect a numeric count prefix such as 12G or 5l
+	if isDigit(key) && (key != "0" || a.count != "") {
+		if len(a.count) < maxCountDigits {
+			a.count += key
+		}
+		return a, nil
+	}
+	count := a.takeCount()
+
+	// ? Escape cancels a pending count or search instead of quitting
+	if key == "esc" && (count > 0 || a.search.active()) {
+		if count == 0 {
+			a.clearSearch()
+		}
+		return a, nil
+	}
+
 	// Check quit keys
-	if a.containsKey(a.config.Keybindings.Quit, key) {
+	if containsKey(a.config.Keybindings.Quit, key) {
 		return a, tea.Quit
 	}
 
@@ -108,26 +245,192 @@ func (a *App) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a, nil
 	}
 
+	// Pause or reset the talk timer
+	if key == "t" {
+		a.timer.Toggle()
+		a.broadcast()
+		return a, nil
+	}
+	if key == "T" {
+		a.timer.Reset()
+		a.broadcast()
+		return a, nil
+	}
+
+	// Switch to the next color palette
+	if key == "c" {
+		a.nextPalette()
+		return a, nil
+	}
+
+	// Run a code block of the slide, the nth one with a count
+	if key == "x" {
+		return a, a.requestRun(count)
+	}
+
+	// Focus the slide terminal
+	if key == "ctrl+t" {
+		return a, a.focusTerminal()
+	}
+
+	// Open the slide overview
+	if key == "o" {
+		a.openOverview()
+		return a, nil
+	}
+
+	// Open the jump prompt
+	if key == ":" {
+		a.prompt.open(":")
+		a.promptFor = promptJump
+		return a, nil
+	}
+
+	// Search slides
+	if key == "/" {
+		a.openSearch()
+		return a, nil
+	}
+	if a.search.active() && (key == "n" || key == "N") {
+		a.cycleSearch(key == "n")
+		return a, nil
+	}
+
 	// Navigation keys
-	if a.containsKey(a.config.Keybindings.Next, key) {
-		// If on last slide and pressing next, exit the presentation
-		if a.navigator.IsLast() {
+	if containsKey(a.config.Keybindings.Next, key) {
+		if count > 0 {
+			a.jump(count)
+			return a, nil
+		}
+
+		// If the last slide is fully revealed and pressing next, exit the presentation
+		if a.navigator.AtEnd() {
 			return a, tea.Quit
 		}
 		a.navigator.Next()
-	} else if a.containsKey(a.config.Keybindings.Previous, key) {
+	} else if containsKey(a.config.Keybindings.Previous, key) {
+		if count > 0 {
+			a.jump(-count)
+			return a, nil
+		}
 		a.navigator.Previous()
-	} else if a.containsKey(a.config.Keybindings.First, key) {
+	} else if containsKey(a.config.Keybindings.First, key) {
+		if count > 0 {
+			a.goToSlide(count)
+			return a, nil
+		}
 		a.navigator.First()
-	} else if a.containsKey(a.config.Keybindings.Last, key) {
+	} else if containsKey(a.config.Keybindings.Last, key) {
+		if count > 0 {
+			a.goToSlide(count)
+			return a, nil
+		}
 		a.navigator.Last()
 	} else if key == "b" {
 		a.navigator.Back()
+	} else if key == "j" || key == "down" {
+		a.scrollBy(max(count, 1))
+	} else if key == "k" || key == "up" {
+		a.scrollBy(-max(count, 1))
 	}
 
 	return a, nil
 }
 
+// newRenderer creates the renderer for the current size, theme and deck
+func (a *App) newRenderer() error {
+	r, err := display.New(a.config, a.theme.GetColorScheme(), a.width, a.height-footerLines)
+	if err != nil {
+		return err
+	}
+	r.SetBaseDir(filepath.Dir(a.presentation.FilePath))
+	if err := r.SetLayout(a.presentation.Layout()); err != nil {
+		return err
+	}
+	r.SetHighlight(a.search.pattern)
+
+	a.renderer = r
+	return nil
+}
+
+// nextPalette draws the chrome in the next palette, bundled or from the
+// config, and names it in the footer
+func (a *App) nextPalette() {
+	name := a.theme.NextPalette()
+	a.palette = name
+	if a.renderer != nil {
+		a.renderer.SetColorScheme(a.theme.GetColorScheme())
+	}
+	a.message = "Palette: " + name
+}
+
+// scrollBy moves the slide body, keeping it within the rendered lines
+func (a *App) scrollBy(lines int) {
+	a.scroll = min(max(a.scroll+lines, 0), a.maxScroll())
+}
+
+// maxScroll returns how far the current slide can scroll, output of code
+// run from it included
+func (a *App) maxScroll() int {
+	slide, err := a.navigator.CurrentSlide()
+	if err != nil || a.renderer == nil {
+		return 0
+	}
+
+	limit, err := a.renderer.MaxScroll(slide, a.navigator.CurrentFragment(), a.outputs(a.navigator.CurrentIndex()))
+	if err != nil {
+		return 0
+	}
+	return limit
+}
+
+func (a *App) handlePromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.promptFor == promptSearch {
+		return a.handleSearchKey(msg)
+	}
+
+	switch a.prompt.handleKey(msg) {
+	case promptCancelled:
+		a.prompt.close()
+	case promptSubmitted:
+		index, err := a.navigator.Resolve(a.prompt.value())
+		if err != nil {
+			a.prompt.fail(err)
+			return a, nil
+		}
+
+		_ = a.navigator.GoTo(index)
+		a.prompt.close()
+	}
+
+	return a, nil
+}
+
+func isDigit(key string) bool {
+	return len(key) == 1 && key[0] >= '0' && key[0] <= '9'
+}
+
+func (a *App) takeCount() int {
+	count, err := strconv.Atoi(a.count)
+	a.count = ""
+	if err != nil {
+		return 0
+	}
+	return count
+}
+
+// jump moves by a relative number of slides, stopping at either end
+func (a *App) jump(offset int) {
+	target := min(max(a.navigator.CurrentIndex()+offset, 0), a.navigator.TotalSlides()-1)
+	_ = a.navigator.GoTo(target)
+}
+
+func (a *App) goToSlide(number int) {
+	if err := a.navigator.GoToSlideNumber(number); err != nil {
+		a.notice = fmt.Sprintf("No slide %d (1-%d)", number, a.navigator.TotalSlides())
+	}
+}
+
 func (a *App) renderHelp() string {
 	helpStyle := lipgloss.NewStyle().
 		Width(a.width).
@@ -148,11 +451,38 @@ func (a *App) renderHelp() string {
 	help.WriteString(fmt.Sprintf("  First slide:    %s\n", strings.Join(a.config.Keybindings.First, ", ")))
 	help.WriteString(fmt.Sprintf("  Last slide:     %s\n", strings.Join(a.config.Keybindings.Last, ", ")))
 	help.WriteString("  Go back:        b\n")
+	help.WriteString("  Scroll slide:   j/k, ↓/↑, mouse wheel\n")
+	help.WriteString("  Go to slide:    <n>G, or : then a number, title or +/-offset\n")
+	help.WriteString("  Jump n slides:  <n> before next or previous\n")
+	help.WriteString("\n")
+
+	// * Search
+	help.WriteString(a.theme.SubtitleStyle().Render("Search:"))
+	help.WriteString("\n")
+	help.WriteString("  Search slides:  / (Ctrl+R toggles regex, upper case matches case)\n")
+	help.WriteString("  Next match:     n\n")
+	help.WriteString("  Previous match: N\n")
+	help.WriteString("  Clear search:   esc\n")
 	help.WriteString("\n")
 
 	// * Other
+	help.WriteString(a.theme.SubtitleStyle().Render("Timer:"))
+	help.WriteString("\n")
+	help.WriteString("  Pause/resume:   t\n")
+	help.WriteString("  Reset:          T\n")
+	help.WriteString("\n")
+
+	help.WriteString(a.theme.SubtitleStyle().Render("Code:"))
+	help.WriteString("\n")
+	help.WriteString("  Run code block: x, <n>x for the nth block\n")
+	help.WriteString("  Stop running:   ctrl+c\n")
+	help.WriteString("  Terminal focus: ctrl+t\n")
+	help.WriteString("\n")
+
 	help.WriteString(a.theme.SubtitleStyle().Render("Other:"))
 	help.WriteString("\n")
+	help.WriteString("  Overview:       o\n")
+	help.WriteString("  Color palette:  c\n")
 	help.WriteString("  Show help:      ?\n")
 	help.WriteString(fmt.Sprintf("  Quit:           %s\n", strings.Join(a.config.Keybindings.Quit, ", ")))
 	help.WriteString("\n\n")
@@ -162,12 +492,50 @@ func (a *App) renderHelp() string {
 	return helpStyle.Render(help.String())
 }
 
+func (a *App) renderFooter() string {
+	switch {
+	case a.prompt.active:
+		return a.prompt.view(a.width, a.theme)
+	case a.confirming != nil:
+		return a.renderConfirm()
+	case a.termFocused:
+		return a.theme.HelpStyle().Width(a.width).Align(lipgloss.Center).
+			Render("Keys go to the terminal  •  ctrl+t returns to the slides")
+	case a.notice != "":
+		return a.theme.ErrorStyle().Width(a.width).Align(lipgloss.Center).Render(a.notice)
+	case a.message != "":
+		return a.theme.HelpStyle().Width(a.width).Align(lipgloss.Center).Render(a.message)
+	}
+
+	// ? Point out unreadable slide colors while the deck is being edited
+	if warning := a.colorWarning(); warning != "" {
+		return a.theme.WarningStyle().Width(a.width).Align(lipgloss.Center).Render(warning)
+	}
+
+	return a.renderCommandFooter()
+}
+
+// colorWarning describes a problem with the colors of the current slide.
+// Only shown in watch mode, so the audience never sees it.
+func (a *App) colorWarning() string {
+	if a.watcher == nil {
+		return ""
+	}
+
+	slide, err := a.navigator.CurrentSlide()
+	if err != nil {
+		return ""
+	}
+
+	return a.renderer.ColorWarning(slide)
+}
+
 func (a *App) renderCommandFooter() string {
 	var commands []string
 
 	// ? Show different commands based on position
 	isFirst := a.navigator.IsFirst()
-	isLast := a.navigator.IsLast()
+	isLast := a.navigator.AtEnd()
 
 	if !isFirst {
 		commands = append(commands, "← Prev")
@@ -183,12 +551,27 @@ func (a *App) renderCommandFooter() string {
 	commands = append(commands, "? Help")
 	commands = append(commands, "Q Quit")
 
+	// ? Show where we are among the search matches
+	if a.search.active() {
+		commands = append(commands, a.searchStatus())
+	}
+
+	// ? Show a pending count prefix
+	if a.count != "" {
+		commands = append(commands, a.count+"…")
+	}
+
+	// ? Show the talk timer once the deck sets a length or it is paused
+	if status := a.timerStatus(); status != "" {
+		commands = append(commands, status)
+	}
+
 	// * Join commands with separator
 	commandText := strings.Join(commands, "  •  ")
 
 	// * Style the footer
 	footerStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(a.theme.GetColorScheme().Muted).
 		Width(a.width).
 		Align(lipgloss.Center).
 		Faint(true)
@@ -196,7 +579,7 @@ func (a *App) renderCommandFooter() string {
 	// * Add special message on last slide
 	if isLast {
 		endMessage := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214")).
+			Foreground(a.theme.GetColorScheme().Warning).
 			Bold(true).
 			Render("  [Press Q to exit]")
 		commandText += endMessage
@@ -206,30 +589,118 @@ func (a *App) renderCommandFooter() string {
 }
 
 func (a *App) Init() tea.Cmd {
-	return nil
+	cmds := make([]tea.Cmd, 0)
+
+	if a.server != nil {
+		a.broadcast()
+		cmds = append(cmds, waitForCommand(a.server.Commands()))
+	}
+
+	if a.watcher != nil {
+		cmds = append(cmds, waitForChange(a.watcher))
+	}
+
+	cmds = append(cmds, a.keepTicking())
+
+	return tea.Batch(cmds...)
 }
 
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	before := a.navigator.Position()
+	scroll := a.scroll
+	presenting := a.viewMode == ViewPresentation
+
+	model, cmd := a.update(msg)
+	_, reloaded := msg.(fileChangedMsg)
+
+	// ? Keep presenter consoles in sync with local navigation, reloads
+	// broadcast the new plan themselves
+	if after := a.navigator.Position(); after != before {
+		if !reloaded {
+			a.broadcast()
+		}
+
+		// ? Each slide starts scrolled to the top, and its terminal stops
+		if after.Slide != before.Slide {
+			a.scroll = 0
+			a.closeTerminal()
+
+			// ? Animate moves between slides, but not reloads or jumps from the overview
+			if presenting && a.viewMode == ViewPresentation && !reloaded {
+				cmd = tea.Batch(cmd, a.startTransition(before, scroll))
+			}
+		}
+	}
+
+	// ? Search matches below the fold are scrolled into view
+	if a.revealMatch {
+		a.revealMatch = false
+		a.scroll = a.matchScroll()
+	}
+
+	// ? Starting the timer or a reload may put a clock on screen
+	return model, tea.Batch(cmd, a.keepTicking())
+}
+
+func (a *App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case remoteCommandMsg:
+		applyCommand(a.navigator, remote.Message(msg))
+		return a, waitForCommand(a.server.Commands())
+
+	case fileChangedMsg:
+		a.stopTransition()
+		a.reload()
+		return a, waitForChange(a.watcher)
+
+	case transitionFrameMsg:
+		return a, a.handleTransitionFrame(msg)
+
+	case runEventMsg:
+		return a, a.handleRunEvent(msg)
+
+	case terminalMsg:
+		return a, a.handleTerminalMsg(msg)
+
+	case terminalExitMsg:
+		a.handleTerminalExit(msg)
+		return a, nil
+
+	case tickMsg:
+		// ? Bubble Tea redraws after every message, which moves the clocks on
+		a.ticking = false
+		return a, a.keepTicking()
+
 	case tea.KeyMsg:
 		return a.handleKeyPress(msg)
 
+	case tea.MouseMsg:
+		if a.viewMode == ViewPresentation && msg.Action == tea.MouseActionPress {
+			switch msg.Button {
+			case tea.MouseButtonWheelUp:
+				a.scrollBy(-wheelScrollLines)
+			case tea.MouseButtonWheelDown:
+				a.scrollBy(wheelScrollLines)
+			}
+		}
+		return a, nil
+
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
+		a.stopTransition()
 
 		// Create or update renderer
 		if a.renderer == nil {
-			r, err := display.New(a.config, a.width, a.height)
-			if err != nil {
+			if err := a.newRenderer(); err != nil {
 				a.err = err
 				return a, tea.Quit
 			}
-			a.renderer = r
 		} else {
-			a.renderer.Resize(a.width, a.height)
+			a.renderer.Resize(a.width, a.height-footerLines)
 			a.renderer.ClearCache(a.presentation)
 		}
+		a.resizeTerminal()
 
 		a.ready = true
 		return a, nil
@@ -256,40 +727,77 @@ func (a *App) View() string {
 		return a.renderHelp()
 	}
 
-	// Get current slide
-	slide, err := a.navigator.CurrentSlide()
-	if err != nil {
-		return a.renderer.RenderError(err)
+	// Show slide overview
+	if a.viewMode == ViewOverview {
+		return a.renderOverview()
 	}
 
 	// Render slide with progress
-	rendered, err := a.renderer.RenderWithProgress(
-		slide,
-		a.navigator.CurrentIndex(),
-		a.navigator.TotalSlides(),
-	)
+	rendered, err := a.renderScreen(a.navigator.Position(), a.scroll)
 	if err != nil {
 		return a.renderer.RenderError(err)
 	}
 
-	// Add command footer
-	footer := a.renderCommandFooter()
+	// Blend in the previous slide while a transition runs
+	if a.transition.running {
+		rendered = display.ComposeTransition(a.transition.kind, a.transition.from, rendered,
+			a.transition.progress(), a.width, a.height-footerLines)
+	}
+
+	// Keep the reload error in view while the file is broken
+	if a.reloadErr != nil {
+		rendered = display.Overlay(rendered, a.renderReloadError(), a.width, a.height-footerLines)
+	}
 
-	return rendered + "\n" + footer
+	return rendered + "\n" + a.renderFooter()
 }
 
-func Run(filepath string) error {
+func Run(filepath string, opts Options) error {
 	app, err := New(filepath)
 	if err != nil {
 		return err
 	}
 
+	if opts.Presenter {
+		server, err := remote.Listen(filepath)
+		if err != nil {
+			return fmt.Errorf("failed to start presenter server: %w", err)
+		}
+		defer func() {
+			_ = server.Close()
+		}()
+
+		app.server = server
+	}
+
+	if opts.NoAnimations {
+		app.config.Presentation.DisableAnimations = true
+	}
+
+	if opts.AllowExec {
+		app.execAllowed = true
+	}
+
+	if opts.Watch {
+		watcher := watch.New(filepath)
+		watchIncludes(watcher, app.presentation, app.config.Theme.GlamourStyle)
+		watcher.Start()
+		defer watcher.Close()
+
+		app.watcher = watcher
+	}
+
 	p := tea.NewProgram(
 		app,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
 
+	// ? Code and terminals run in process groups of their own, which would
+	// outlive slate
+	defer app.closeTerminal()
+	defer app.cancelRuns()
+
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("error running program: %w", err)
 	}
@@ -0,0 +1,287 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestApp starts an app on a deck written to a temporary directory, with
+// the default config and a terminal of the given size
+func newTestApp(t *testing.T, content string, width, height int) *App {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	path := filepath.Join(t.TempDir(), "slides.md")
+	writeDeck(t, path, content)
+
+	a, err := New(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	a.Update(tea.WindowSizeMsg{Width: width, Height: height})
+
+	return a
+}
+
+func writeDeck(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// keyMsg builds the message for a named key or typed text
+func keyMsg(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "pgdown":
+		return tea.KeyMsg{Type: tea.KeyPgDown}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+}
+
+// press sends each key to the app in turn
+func press(a *App, keys ...string) {
+	for _, key := range keys {
+		a.Update(keyMsg(key))
+	}
+}
+
+// numberedDeck returns a deck of count slides titled Slide 1, Slide 2, ...
+func numberedDeck(count int) string {
+	slides := make([]string, count)
+	for i := range slides {
+		slides[i] = fmt.Sprintf("# Slide %d", i+1)
+	}
+	return strings.Join(slides, "\n\n---\n\n")
+}
+
+func isQuit(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	_, ok := cmd().(tea.QuitMsg)
+	return ok
+}
+
+func TestCountPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    int
+		keys     []string
+		expected int
+	}{
+		{"Count before next", 0, []string{"3", "l"}, 3},
+		{"Count before previous", 5, []string{"2", "h"}, 3},
+		{"Several digits", 0, []string{"1", "1", "l"}, 11},
+		{"Next stops at the end", 8, []string{"9", "l"}, 11},
+		{"Previous stops at the start", 2, []string{"9", "h"}, 0},
+		{"Count before G", 0, []string{"7", "G"}, 6},
+		{"Count before g", 9, []string{"2", "g"}, 1},
+		{"Slide number out of range", 4, []string{"9", "9", "G"}, 4},
+		{"Leading zero is not a count", 4, []string{"0", "l"}, 5},
+		{"Escape drops the count", 4, []string{"3", "esc", "l"}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t, numberedDeck(12), 80, 24)
+			_ = a.navigator.GoTo(tt.start)
+
+			press(a, tt.keys...)
+
+			if got := a.navigator.CurrentIndex(); got != tt.expected {
+				t.Errorf("Expected slide index %d, got %d", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("Notice for missing slide", func(t *testing.T) {
+		a := newTestApp(t, numberedDeck(12), 80, 24)
+		press(a, "9", "9", "G")
+
+		if !strings.Contains(a.notice, "No slide 99") {
+			t.Errorf("Expected a notice about slide 99, got %q", a.notice)
+		}
+	})
+
+	t.Run("Escape with a count does not quit", func(t *testing.T) {
+		a := newTestApp(t, numberedDeck(12), 80, 24)
+		press(a, "3")
+
+		if _, cmd := a.Update(keyMsg("esc")); isQuit(cmd) {
+			t.Error("Expected escape to cancel the count, not quit")
+		}
+	})
+}
+
+func TestJumpPrompt(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    int
+		keys     []string
+		expected int
+	}{
+		{"Slide number", 0, []string{":", "4", "enter"}, 3},
+		{"Title", 0, []string{":", "Slide 10", "enter"}, 9},
+		{"Forward offset", 2, []string{":", "+3", "enter"}, 5},
+		{"Backward offset", 6, []string{":", "-2", "enter"}, 4},
+		{"Edited input", 0, []string{":", "5", "backspace", "2", "enter"}, 1},
+		{"Cancelled", 3, []string{":", "8", "esc"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t, numberedDeck(12), 80, 24)
+			_ = a.navigator.GoTo(tt.start)
+
+			press(a, tt.keys...)
+
+			if got := a.navigator.CurrentIndex(); got != tt.expected {
+				t.Errorf("Expected slide index %d, got %d", tt.expected, got)
+			}
+			if a.prompt.active {
+				t.Error("Expected the prompt to close")
+			}
+		})
+	}
+
+	t.Run("Invalid target keeps the prompt open", func(t *testing.T) {
+		a := newTestApp(t, numberedDeck(12), 80, 24)
+		press(a, ":", "nowhere", "enter")
+
+		if !a.prompt.active {
+			t.Fatal("Expected the prompt to stay open")
+		}
+		if a.prompt.err == "" {
+			t.Error("Expected the prompt to show an error")
+		}
+		if got := a.navigator.CurrentIndex(); got != 0 {
+			t.Errorf("Expected to stay on slide index 0, got %d", got)
+		}
+
+		// ? Typing clears the error
+		press(a, "backspace")
+		if a.prompt.err != "" {
+			t.Errorf("Expected the error to clear, got %q", a.prompt.err)
+		}
+	})
+
+	t.Run("Keys go to the prompt", func(t *testing.T) {
+		a := newTestApp(t, numberedDeck(12), 80, 24)
+		press(a, ":", "q", "l")
+
+		if got := a.prompt.value(); got != "ql" {
+			t.Errorf("Expected the prompt to hold %q, got %q", "ql", got)
+		}
+		if got := a.navigator.CurrentIndex(); got != 0 {
+			t.Errorf("Expected to stay on slide index 0, got %d", got)
+		}
+	})
+}
+
+// tallDeck has a first slide taller than any test window and a short second
+func tallDeck() string {
+	lines := make([]string, 60)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("Line %d", i+1)
+	}
+	return "# Tall\n\n" + strings.Join(lines, "\n\n") + "\n\n---\n\n# Short"
+}
+
+func wheel(button tea.MouseButton) tea.MouseMsg {
+	return tea.MouseMsg{Action: tea.MouseActionPress, Button: button}
+}
+
+func TestScroll(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []string
+		expected func(limit int) int
+	}{
+		{"Down", []string{"j"}, func(int) int { return 1 }},
+		{"Down with a count", []string{"5", "j"}, func(int) int { return 5 }},
+		{"Arrow keys", []string{"down", "down", "up"}, func(int) int { return 1 }},
+		{"Up stops at the top", []string{"j", "3", "k"}, func(int) int { return 0 }},
+		{"Down stops at the bottom", []string{"9", "9", "9", "j"}, func(limit int) int { return limit }},
+		{"Next slide starts at the top", []string{"5", "j", "l"}, func(int) int { return 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t, tallDeck(), 80, 24)
+			limit := a.maxScroll()
+			if limit < 10 {
+				t.Fatalf("Expected the tall slide to scroll, got a limit of %d", limit)
+			}
+
+			press(a, tt.keys...)
+
+			if expected := tt.expected(limit); a.scroll != expected {
+				t.Errorf("Expected scroll %d, got %d", expected, a.scroll)
+			}
+		})
+	}
+
+	t.Run("Mouse wheel", func(t *testing.T) {
+		a := newTestApp(t, tallDeck(), 80, 24)
+
+		a.Update(wheel(tea.MouseButtonWheelDown))
+		a.Update(wheel(tea.MouseButtonWheelDown))
+		if a.scroll != 2*wheelScrollLines {
+			t.Errorf("Expected scroll %d, got %d", 2*wheelScrollLines, a.scroll)
+		}
+
+		a.Update(wheel(tea.MouseButtonWheelUp))
+		a.Update(wheel(tea.MouseButtonWheelUp))
+		a.Update(wheel(tea.MouseButtonWheelUp))
+		if a.scroll != 0 {
+			t.Errorf("Expected the wheel to stop at the top, got %d", a.scroll)
+		}
+
+		for range 100 {
+			a.Update(wheel(tea.MouseButtonWheelDown))
+		}
+		if limit := a.maxScroll(); a.scroll != limit {
+			t.Errorf("Expected the wheel to stop at %d, got %d", limit, a.scroll)
+		}
+	})
+
+	t.Run("Short slide does not scroll", func(t *testing.T) {
+		a := newTestApp(t, tallDeck(), 80, 24)
+		press(a, "l", "j")
+		a.Update(wheel(tea.MouseButtonWheelDown))
+
+		if a.scroll != 0 {
+			t.Errorf("Expected scroll 0, got %d", a.scroll)
+		}
+	})
+
+	t.Run("Wheel is ignored in the overview", func(t *testing.T) {
+		a := newTestApp(t, tallDeck(), 80, 24)
+		press(a, "o")
+		a.Update(wheel(tea.MouseButtonWheelDown))
+
+		if a.scroll != 0 {
+			t.Errorf("Expected scroll 0, got %d", a.scroll)
+		}
+	})
+}
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/Kosha-Nirman/slate/src/runner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// * Lines of output kept for each run, older ones are dropped
+const maxOutputLines = 200
+
+// * runKey names a code block by its slide and its place on the slide
+type runKey struct {
+	slide int
+	block int
+}
+
+// * codeRun is a code block started from a slide and what it printed
+type codeRun struct {
+	process *runner.Process
+	output  string
+	status  string
+	running bool
+}
+
+// * confirmation asks the presenter before code from the deck runs
+type confirmation struct {
+	question string
+	accept   func() tea.Cmd
+}
+
+// * runEventMsg carries output from a running code block
+type runEventMsg struct {
+	key     runKey
+	event   runner.Event
+	process *runner.Process
+}
+
+func waitForRun(key runKey, process *runner.Process) tea.Cmd {
+	return func() tea.Msg {
+		event, ok := <-process.Events()
+		if !ok {
+			return nil
+		}
+		return runEventMsg{key: key, event: event, process: process}
+	}
+}
+
+// requestRun runs the nth code block of the current slide, first asking
+// the presenter unless the deck is allowed to run code
+func (a *App) requestRun(count int) tea.Cmd {
+	slide, err := a.navigator.CurrentSlide()
+	if err != nil {
+		return nil
+	}
+
+	if len(slide.CodeBlocks) == 0 {
+		a.notice = "No code to run on this slide"
+		return nil
+	}
+	block := max(count, 1) - 1
+	if block >= len(slide.CodeBlocks) {
+		a.notice = fmt.Sprintf("No code block %d (1-%d)", block+1, len(slide.CodeBlocks))
+		return nil
+	}
+
+	key := runKey{slide: a.navigator.CurrentIndex(), block: block}
+	if run, ok := a.runs[key]; ok && run.running {
+		return nil
+	}
+
+	// ? Never run code from a deck without the presenter agreeing to it
+	if !a.execAllowed {
+		a.confirming = &confirmation{
+			question: fmt.Sprintf("Run %s code from this deck on your machine?", slide.CodeBlocks[block].Language),
+			accept:   func() tea.Cmd { return a.startRun(key) },
+		}
+		return nil
+	}
+
+	return a.startRun(key)
+}
+
+// handleConfirmKey answers the question whether to run code from the deck.
+// Agreeing holds for the rest of the session.
+func (a *App) handleConfirmKey(key string) tea.Cmd {
+	pending := a.confirming
+	a.confirming = nil
+
+	if key != "y" && key != "Y" {
+		return nil
+	}
+
+	a.execAllowed = true
+	return pending.accept()
+}
+
+func (a *App) startRun(key runKey) tea.Cmd {
+	slide, err := a.navigator.GetSlideAt(key.slide)
+	if err != nil || key.block >= len(slide.CodeBlocks) {
+		return nil
+	}
+
+	process, err := runner.Start(slide.CodeBlocks[key.block], a.config.Exec, filepath.Dir(a.presentation.FilePath))
+	if err != nil {
+		a.notice = err.Error()
+		return nil
+	}
+
+	if a.runs == nil {
+		a.runs = make(map[runKey]*codeRun)
+	}
+	a.runs[key] = &codeRun{process: process, status: "running…", running: true}
+
+	return waitForRun(key, process)
+}
+
+func (a *App) handleRunEvent(msg runEventMsg) tea.Cmd {
+	next := waitForRun(msg.key, msg.process)
+	if msg.event.Done {
+		next = nil
+	}
+
+	// ? Runs replaced or forgotten since are drained but no longer shown
+	run, ok := a.runs[msg.key]
+	if !ok || run.process != msg.process {
+		return next
+	}
+
+	// ? Follow the output while the slide is scrolled to the bottom
+	current := msg.key.slide == a.navigator.CurrentIndex()
+	follow := current && a.scroll >= a.maxScroll()
+
+	if msg.event.Done {
+		run.running = false
+		run.status = runStatus(msg.event.Err)
+	} else {
+		run.output = trimOutput(run.output + msg.event.Output)
+	}
+
+	if follow {
+		a.scroll = a.maxScroll()
+	}
+
+	return next
+}
+
+func runStatus(err error) string {
+	var exitErr interface{ ExitCode() int }
+	switch {
+	case err == nil:
+		return "done"
+	case errors.Is(err, runner.ErrCancelled):
+		return "cancelled"
+	case errors.As(err, &exitErr):
+		return fmt.Sprintf("exit status %d", exitErr.ExitCode())
+	}
+	return err.Error()
+}
+
+// trimOutput keeps the last lines of output
+func trimOutput(output string) string {
+	lines := strings.Split(output, "\n")
+	if len(lines) <= maxOutputLines {
+		return output
+	}
+	return strings.Join(lines[len(lines)-maxOutputLines:], "\n")
+}
+
+// cancelRuns stops every running block, reporting whether any was running
+func (a *App) cancelRuns() bool {
+	cancelled := false
+	for _, run := range a.runs {
+		if run.running {
+			run.process.Cancel()
+			cancelled = true
+		}
+	}
+	return cancelled
+}
+
+// resetRuns stops every run and forgets their output, for when the slides
+// they belong to may have changed
+func (a *App) resetRuns() {
+	a.cancelRuns()
+	a.runs = nil
+	a.confirming = nil
+}
+
+// outputs returns what the code blocks of a slide printed
+func (a *App) outputs(slide int) map[int]display.CodeOutput {
+	var outputs map[int]display.CodeOutput
+	for key, run := range a.runs {
+		if key.slide != slide {
+			continue
+		}
+		if outputs == nil {
+			outputs = make(map[int]display.CodeOutput)
+		}
+		outputs[key.block] = display.CodeOutput{Text: run.output, Status: run.status}
+	}
+	return outputs
+}
+
+func (a *App) renderConfirm() string {
+	question := a.confirming.question + " y to run, any other key to cancel"
+	return a.theme.WarningStyle().Width(a.width).Align(lipgloss.Center).Render(question)
+}
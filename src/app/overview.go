@@ -0,0 +1,173 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// * Smallest thumbnail cell, including its border
+	minCellWidth  = 28
+	minCellHeight = 8
+)
+
+// * overview tracks the cursor of the slide grid
+type overview struct {
+	cursor int
+	// top is the first visible row when the grid is taller than the window
+	top int
+}
+
+func (a *App) openOverview() {
+	a.viewMode = ViewOverview
+	a.overview = overview{cursor: a.navigator.CurrentIndex()}
+}
+
+// overviewGrid returns the number of columns and rows that fit the window
+// along with the size of each cell
+func (a *App) overviewGrid() (columns, rows, cellWidth, cellHeight int) {
+	// ? Leave room for the heading and footer lines
+	bodyHeight := max(a.height-2, minCellHeight)
+
+	columns = max(a.width/minCellWidth, 1)
+	rows = max(bodyHeight/minCellHeight, 1)
+
+	return columns, rows, a.width / columns, bodyHeight / rows
+}
+
+func (a *App) handleOverviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	columns, rows, _, _ := a.overviewGrid()
+	total := a.navigator.TotalSlides()
+	cursor := a.overview.cursor
+
+	switch key {
+	case "o", "esc":
+		a.viewMode = ViewPresentation
+		return a, nil
+	case "enter", " ":
+		_ = a.navigator.GoTo(cursor)
+		a.viewMode = ViewPresentation
+		return a, nil
+	case "left", "h":
+		cursor--
+	case "right", "l":
+		cursor++
+	case "up", "k":
+		cursor -= columns
+	case "down", "j":
+		cursor += columns
+	case "pgup":
+		cursor -= columns * rows
+	case "pgdown":
+		cursor += columns * rows
+	case "home", "g":
+		cursor = 0
+	case "end", "G":
+		cursor = total - 1
+	default:
+		if containsKey(a.config.Keybindings.Quit, key) {
+			return a, tea.Quit
+		}
+		return a, nil
+	}
+
+	a.overview.cursor = min(max(cursor, 0), total-1)
+
+	// * Scroll so the cursor row stays visible
+	row := a.overview.cursor / columns
+	if row < a.overview.top {
+		a.overview.top = row
+	} else if row >= a.overview.top+rows {
+		a.overview.top = row - rows + 1
+	}
+
+	return a, nil
+}
+
+func (a *App) renderOverviewCell(index, width, height int) string {
+	slide, err := a.navigator.GetSlideAt(index)
+	if err != nil {
+		return ""
+	}
+
+	colors := a.theme.GetColorScheme()
+	innerWidth := max(width-2, 1)
+	innerHeight := max(height-2, 1)
+
+	title := slide.Title()
+	if title == "" {
+		title = fmt.Sprintf("Slide %d", index+1)
+	}
+	heading := fmt.Sprintf("%d. %s", index+1, title)
+
+	headingStyle := lipgloss.NewStyle().Bold(true).Foreground(colors.Secondary)
+	border := lipgloss.RoundedBorder()
+	borderColor := colors.Border
+
+	// ? Mark the slide on screen and the one under the cursor
+	if index == a.navigator.CurrentIndex() {
+		heading = "● " + heading
+		headingStyle = headingStyle.Foreground(colors.Accent)
+	}
+	if index == a.overview.cursor {
+		border = lipgloss.ThickBorder()
+		borderColor = colors.Primary
+	}
+
+	heading = lipgloss.NewStyle().MaxWidth(innerWidth).Render(headingStyle.Render(heading))
+
+	body, err := a.renderer.RenderThumbnail(slide, innerWidth, innerHeight-1)
+	if err != nil {
+		body = a.theme.ErrorStyle().Render(err.Error())
+	}
+
+	return lipgloss.NewStyle().
+		Width(innerWidth).
+		Height(innerHeight).
+		MaxHeight(height).
+		BorderStyle(border).
+		BorderForeground(borderColor).
+		Render(heading + "\n" + body)
+}
+
+func (a *App) renderOverview() string {
+	columns, rows, cellWidth, cellHeight := a.overviewGrid()
+	total := a.navigator.TotalSlides()
+
+	gridRows := make([]string, 0, rows)
+	for row := a.overview.top; row < a.overview.top+rows; row++ {
+		cells := make([]string, 0, columns)
+		for column := range columns {
+			index := row*columns + column
+			if index >= total {
+				break
+			}
+			cells = append(cells, a.renderOverviewCell(index, cellWidth, cellHeight))
+		}
+		if len(cells) == 0 {
+			break
+		}
+		gridRows = append(gridRows, lipgloss.JoinHorizontal(lipgloss.Top, cells...))
+	}
+
+	heading := a.theme.TitleStyle().
+		Width(a.width).
+		Align(lipgloss.Center).
+		Render(fmt.Sprintf("Overview · %d / %d", a.overview.cursor+1, total))
+
+	grid := lipgloss.NewStyle().
+		Height(a.height - 2).
+		MaxHeight(a.height - 2).
+		Render(strings.Join(gridRows, "\n"))
+
+	footer := a.theme.HelpStyle().
+		Width(a.width).
+		Align(lipgloss.Center).
+		Render("←↑↓→ Move  •  Enter Go to slide  •  O/Esc Back")
+
+	return lipgloss.JoinVertical(lipgloss.Left, heading, grid, footer)
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOverviewGrid(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		columns, rows int
+		cellWidth     int
+		cellHeight    int
+	}{
+		{"Wide window", 120, 26, 4, 3, 30, 8},
+		{"Standard window", 80, 24, 2, 2, 40, 11},
+		{"Narrow window", 20, 24, 1, 2, 20, 11},
+		{"Short window", 80, 5, 2, 1, 40, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t, numberedDeck(3), tt.width, tt.height)
+
+			columns, rows, cellWidth, cellHeight := a.overviewGrid()
+			if columns != tt.columns || rows != tt.rows {
+				t.Errorf("Expected %dx%d cells, got %dx%d", tt.columns, tt.rows, columns, rows)
+			}
+			if cellWidth != tt.cellWidth || cellHeight != tt.cellHeight {
+				t.Errorf("Expected cells of %dx%d, got %dx%d", tt.cellWidth, tt.cellHeight, cellWidth, cellHeight)
+			}
+		})
+	}
+}
+
+func TestOverviewSelection(t *testing.T) {
+	// * 120x26 lays out 4 columns and 3 rows
+	tests := []struct {
+		name   string
+		start  int
+		keys   []string
+		cursor int
+		top    int
+	}{
+		{"Opens on the current slide", 5, nil, 5, 0},
+		{"Right", 0, []string{"l"}, 1, 0},
+		{"Left stops at the first", 0, []string{"h"}, 0, 0},
+		{"Down a row", 1, []string{"j"}, 5, 0},
+		{"Up a row", 5, []string{"k"}, 1, 0},
+		{"Up stops at the first", 1, []string{"k"}, 0, 0},
+		{"Down past the grid scrolls", 9, []string{"j"}, 13, 1},
+		{"Last", 0, []string{"G"}, 13, 1},
+		{"Down stops at the last", 12, []string{"j"}, 13, 1},
+		{"Back up keeps the scroll", 0, []string{"G", "k"}, 9, 1},
+		{"Up past the top scrolls", 0, []string{"G", "k", "k", "k"}, 1, 0},
+		{"Page down", 0, []string{"pgdown"}, 12, 1},
+		{"First", 13, []string{"g"}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t, numberedDeck(14), 120, 26)
+			_ = a.navigator.GoTo(tt.start)
+
+			press(a, "o")
+			press(a, tt.keys...)
+
+			if a.viewMode != ViewOverview {
+				t.Fatal("Expected the overview to stay open")
+			}
+			if a.overview.cursor != tt.cursor {
+				t.Errorf("Expected cursor %d, got %d", tt.cursor, a.overview.cursor)
+			}
+			if a.overview.top != tt.top {
+				t.Errorf("Expected top row %d, got %d", tt.top, a.overview.top)
+			}
+			if got := a.navigator.CurrentIndex(); got != tt.start {
+				t.Errorf("Expected the slide to stay at %d until chosen, got %d", tt.start, got)
+			}
+		})
+	}
+}
+
+func TestOverviewChoose(t *testing.T) {
+	t.Run("Enter goes to the slide", func(t *testing.T) {
+		a := newTestApp(t, numberedDeck(14), 120, 26)
+		press(a, "o", "j", "l", "enter")
+
+		if a.viewMode != ViewPresentation {
+			t.Error("Expected the overview to close")
+		}
+		if got := a.navigator.CurrentIndex(); got != 5 {
+			t.Errorf("Expected slide index 5, got %d", got)
+		}
+	})
+
+	t.Run("Escape keeps the slide", func(t *testing.T) {
+		a := newTestApp(t, numberedDeck(14), 120, 26)
+		press(a, "o", "j", "esc")
+
+		if a.viewMode != ViewPresentation {
+			t.Error("Expected the overview to close")
+		}
+		if got := a.navigator.CurrentIndex(); got != 0 {
+			t.Errorf("Expected slide index 0, got %d", got)
+		}
+	})
+
+	t.Run("Shows the visible rows", func(t *testing.T) {
+		a := newTestApp(t, numberedDeck(14), 120, 26)
+		press(a, "o", "G")
+
+		view := a.View()
+		for _, heading := range []string{"5. Slide 5", "14. Slide 14"} {
+			if !strings.Contains(view, heading) {
+				t.Errorf("Expected the overview to show %q", heading)
+			}
+		}
+		if strings.Contains(view, "4. Slide 4") {
+			t.Error("Expected the first row to be scrolled out of view")
+		}
+	})
+}
@@ -0,0 +1,355 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/navigation"
+	"github.com/Kosha-Nirman/slate/src/remote"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/Kosha-Nirman/slate/src/timer"
+	"github.com/Kosha-Nirman/slate/src/watch"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// * tickMsg refreshes clocks once a second
+type tickMsg time.Time
+
+// * Presenter is the BubbleTea model for the presenter console. It mirrors
+// * the audience position and sends navigation back over the socket.
+type Presenter struct {
+	config *models.Config
+	// configTheme is the theme before the deck's front matter is applied
+	configTheme models.ThemeConfig
+
+	presentation *models.Presentation
+
+	theme     *theme.Manager
+	renderer  *display.Renderer
+	navigator *navigation.Navigator
+	client    *remote.Client
+	watcher   *watch.Watcher
+
+	// * Talk timer of the audience view as of received, and the deck's plan
+	elapsed  time.Duration
+	paused   bool
+	received time.Time
+	plan     timer.Plan
+	now      time.Time
+
+	width  int
+	height int
+
+	err   error
+	ready bool
+}
+
+func NewPresenter(filePath string) (*Presenter, error) {
+	cfg, presentation, err := load(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	configTheme := cfg.Theme
+	if cfg.Theme, err = theme.DeckTheme(configTheme, presentation); err != nil {
+		return nil, err
+	}
+
+	client, err := remote.Dial(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Presenter{
+		config:       cfg,
+		configTheme:  configTheme,
+		presentation: presentation,
+		theme:        theme.NewManager(&cfg.Theme),
+		navigator:    navigation.New(presentation),
+		client:       client,
+		received:     time.Now(),
+		now:          time.Now(),
+	}, nil
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func (p *Presenter) Init() tea.Cmd {
+	cmds := []tea.Cmd{waitForState(p.client), tick()}
+
+	if p.watcher != nil {
+		cmds = append(cmds, waitForChange(p.watcher))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func (p *Presenter) reload() {
+	presentation, err := reparse(p.presentation)
+	var themeConfig models.ThemeConfig
+	if err == nil {
+		themeConfig, err = theme.DeckTheme(p.configTheme, presentation)
+	}
+	if err != nil {
+		// ? The audience view reports reload errors, keep the last good version
+		return
+	}
+
+	p.presentation = presentation
+	p.navigator.SetPresentation(presentation, p.navigator.CurrentIndex())
+
+	p.config.Theme = themeConfig
+	p.theme = theme.NewManager(&p.config.Theme)
+	watchIncludes(p.watcher, presentation, p.config.Theme.GlamourStyle)
+
+	// * The renderer holds the style, so it follows the new theme. One that
+	// fails to build keeps the last, as the audience view reports the problem.
+	if p.renderer != nil {
+		_ = p.newRenderer()
+	}
+}
+
+func (p *Presenter) send(command string, slide int) tea.Cmd {
+	if err := p.client.Send(command, slide); err != nil {
+		p.err = err
+		return tea.Quit
+	}
+	return nil
+}
+
+func (p *Presenter) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	keys := p.config.Keybindings
+
+	switch {
+	case containsKey(keys.Quit, key):
+		return p, tea.Quit
+	case containsKey(keys.Next, key):
+		return p, p.send(remote.CommandNext, 0)
+	case containsKey(keys.Previous, key):
+		return p, p.send(remote.CommandPrevious, 0)
+	case containsKey(keys.First, key):
+		return p, p.send(remote.CommandFirst, 0)
+	case containsKey(keys.Last, key):
+		return p, p.send(remote.CommandLast, 0)
+	case key == "b":
+		return p, p.send(remote.CommandBack, 0)
+	}
+
+	return p, nil
+}
+
+func (p *Presenter) paneSizes() (int, int, int) {
+	bodyHeight := max(p.height-3, 1)
+	leftWidth := p.width * 3 / 5
+	rightWidth := p.width - leftWidth - 1
+
+	return leftWidth, rightWidth, bodyHeight
+}
+
+func (p *Presenter) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return p.handleKeyPress(msg)
+
+	case remoteStateMsg:
+		_ = p.navigator.SetPosition(navigation.Position{Slide: msg.Slide, Fragment: msg.Fragment})
+		p.elapsed, p.paused, p.plan = msg.Elapsed, msg.Paused, msg.Plan
+		p.received = time.Now()
+		return p, waitForState(p.client)
+
+	case remoteErrMsg:
+		p.err = msg.err
+		return p, tea.Quit
+
+	case fileChangedMsg:
+		p.reload()
+		return p, waitForChange(p.watcher)
+
+	case tickMsg:
+		p.now = time.Time(msg)
+		return p, tick()
+
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+		p.height = msg.Height
+
+		// * The current slide pane gets its own renderer sized to the pane
+		if err := p.newRenderer(); err != nil {
+			p.err = err
+			return p, tea.Quit
+		}
+
+		p.ready = true
+		return p, nil
+	}
+
+	return p, nil
+}
+
+// newRenderer creates the renderer of the current slide pane
+func (p *Presenter) newRenderer() error {
+	leftWidth, _, bodyHeight := p.paneSizes()
+	r, err := display.New(p.config, p.theme.GetColorScheme(), leftWidth, bodyHeight)
+	if err != nil {
+		return err
+	}
+	r.SetBaseDir(filepath.Dir(p.presentation.FilePath))
+	r.ClearCache(p.presentation)
+
+	p.renderer = r
+	return nil
+}
+
+// talkElapsed is the time on the audience view's timer, counting on from
+// the last update unless it is paused
+func (p *Presenter) talkElapsed() time.Duration {
+	if p.paused {
+		return p.elapsed
+	}
+	return p.elapsed + max(p.now.Sub(p.received), 0)
+}
+
+func (p *Presenter) renderStatus() string {
+	elapsed := p.talkElapsed()
+
+	status := fmt.Sprintf("Slide %s", p.navigator.ProgressText())
+	if count := p.navigator.FragmentCount(); count > 1 {
+		status += fmt.Sprintf(" · Step %d/%d", p.navigator.CurrentFragment()+1, count)
+	}
+
+	// ? With a deck duration, show the time left and pace as the audience footer does
+	timing := p.theme.TitleStyle().Render("Elapsed " + display.FormatDuration(elapsed))
+	if p.paused {
+		timing = p.theme.TitleStyle().Render("⏸ ") + timing
+	}
+	if !p.plan.IsZero() {
+		timing = describeTimer(elapsed, p.paused, p.plan, p.navigator.CurrentIndex(), p.config.Timer, p.theme)
+	}
+	clock := timing + p.theme.TitleStyle().Render("   "+p.now.Format("15:04"))
+
+	gap := max(p.width-lipgloss.Width(status)-lipgloss.Width(clock), 1)
+	return p.theme.TitleStyle().Render(status+strings.Repeat(" ", gap)) + clock
+}
+
+func (p *Presenter) renderNext(width, height int) string {
+	var slide *models.Slide
+	fragment := 0
+
+	// ? The next step is either another fragment or the following slide
+	if p.navigator.HasNextFragment() {
+		slide, _ = p.navigator.CurrentSlide()
+		fragment = p.navigator.CurrentFragment() + 1
+	} else if p.navigator.HasNext() {
+		slide, _ = p.navigator.GetSlideAt(p.navigator.CurrentIndex() + 1)
+	}
+
+	if slide == nil {
+		return p.theme.HelpStyle().Render("End of presentation")
+	}
+
+	preview, err := p.renderer.RenderPreview(slide, fragment, width, height)
+	if err != nil {
+		return p.theme.ErrorStyle().Render(err.Error())
+	}
+	return preview
+}
+
+func (p *Presenter) renderNotes(width int) string {
+	slide, err := p.navigator.CurrentSlide()
+	if err != nil || slide.Metadata.Notes == "" {
+		return p.theme.HelpStyle().Render("No notes for this slide")
+	}
+
+	return lipgloss.NewStyle().Width(width).Render(slide.Metadata.Notes)
+}
+
+func (p *Presenter) View() string {
+	if !p.ready {
+		return "Loading..."
+	}
+
+	if p.err != nil {
+		return p.renderer.RenderError(p.err)
+	}
+
+	leftWidth, rightWidth, bodyHeight := p.paneSizes()
+
+	// * Current slide as the audience sees it
+	slide, err := p.navigator.CurrentSlide()
+	if err != nil {
+		return p.renderer.RenderError(err)
+	}
+
+	current, err := p.renderer.RenderFragment(slide, p.navigator.CurrentFragment())
+	if err != nil {
+		return p.renderer.RenderError(err)
+	}
+	current = lipgloss.NewStyle().MaxWidth(leftWidth).MaxHeight(bodyHeight).Render(current)
+
+	// * Next step preview above the speaker notes
+	previewHeight := bodyHeight / 2
+	right := lipgloss.JoinVertical(lipgloss.Left,
+		p.theme.SubtitleStyle().Render("Next"),
+		p.renderNext(rightWidth, previewHeight-1),
+		"",
+		p.theme.SubtitleStyle().Render("Notes"),
+		p.renderNotes(rightWidth),
+	)
+	right = lipgloss.NewStyle().
+		Width(rightWidth).
+		MaxHeight(bodyHeight).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderLeft(true).
+		BorderForeground(p.theme.GetColorScheme().Border).
+		Render(right)
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Width(leftWidth).Height(bodyHeight).Render(current),
+		right,
+	)
+
+	footer := p.theme.HelpStyle().
+		Width(p.width).
+		Align(lipgloss.Center).
+		Render("← Prev  •  → Next  •  B Back  •  Q Quit presenter")
+
+	return lipgloss.JoinVertical(lipgloss.Left, p.renderStatus(), body, footer)
+}
+
+func RunPresenter(filepath string, opts Options) error {
+	presenter, err := NewPresenter(filepath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = presenter.client.Close()
+	}()
+
+	if opts.Watch {
+		watcher := watch.New(filepath)
+		watchIncludes(watcher, presenter.presentation, presenter.config.Theme.GlamourStyle)
+		watcher.Start()
+		defer watcher.Close()
+
+		presenter.watcher = watcher
+	}
+
+	p := tea.NewProgram(presenter, tea.WithAltScreen())
+
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running program: %w", err)
+	}
+
+	// ? Say why the console closed, such as a lost connection
+	return presenter.err
+}
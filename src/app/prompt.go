@@ -0,0 +1,93 @@
+package app
+
+import (
+	"github.com/Kosha-Nirman/slate/src/theme"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// * promptResult tells the caller what a key press did to the prompt
+type promptResult int
+
+const (
+	promptEditing promptResult = iota
+	promptSubmitted
+	promptCancelled
+)
+
+// * promptPurpose records what the open prompt is for
+type promptPurpose int
+
+const (
+	promptJump promptPurpose = iota
+	promptSearch
+)
+
+// * prompt is a single-line input shown in place of the command footer
+type prompt struct {
+	active bool
+	label  string
+	input  []rune
+	err    string
+}
+
+func (p *prompt) open(label string) {
+	p.active = true
+	p.label = label
+	p.input = nil
+	p.err = ""
+}
+
+func (p *prompt) close() {
+	p.active = false
+	p.input = nil
+	p.err = ""
+}
+
+func (p *prompt) value() string {
+	return string(p.input)
+}
+
+// fail keeps the prompt open and shows why the input was rejected
+func (p *prompt) fail(err error) {
+	p.err = err.Error()
+}
+
+func (p *prompt) handleKey(msg tea.KeyMsg) promptResult {
+	switch msg.Type {
+	case tea.KeyEnter:
+		return promptSubmitted
+	case tea.KeyEsc, tea.KeyCtrlC:
+		return promptCancelled
+	case tea.KeyBackspace:
+		// ? Deleting past the start closes the prompt, as in vim
+		if len(p.input) == 0 {
+			return promptCancelled
+		}
+		p.input = p.input[:len(p.input)-1]
+	case tea.KeyCtrlU:
+		p.input = nil
+	case tea.KeySpace:
+		p.input = append(p.input, ' ')
+	case tea.KeyRunes:
+		p.input = append(p.input, msg.Runes...)
+	default:
+		return promptEditing
+	}
+
+	p.err = ""
+	return promptEditing
+}
+
+func (p *prompt) view(width int, themeManager *theme.Manager) string {
+	line := p.label + string(p.input) + "█"
+	if p.err != "" {
+		line += "  " + themeManager.ErrorStyle().Render(p.err)
+	}
+
+	return lipgloss.NewStyle().
+		Width(width).
+		MaxWidth(width).
+		MaxHeight(1).
+		Render(line)
+}
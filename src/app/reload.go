@@ -0,0 +1,200 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/search"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/Kosha-Nirman/slate/src/timer"
+	"github.com/Kosha-Nirman/slate/src/watch"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// * fileChangedMsg signals that the presentation source changed on disk
+type fileChangedMsg struct{}
+
+func waitForChange(w *watch.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-w.Events(); !ok {
+			return nil
+		}
+		return fileChangedMsg{}
+	}
+}
+
+func reparse(current *models.Presentation) (*models.Presentation, error) {
+	presentation, err := data.New(current.FilePath).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse presentation: %w", err)
+	}
+
+	if err := presentation.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid presentation: %w", err)
+	}
+
+	presentation.Config = current.Config
+	return presentation, nil
+}
+
+// matchSlide finds where the slide at index ended up in the reloaded
+// presentation, preferring the closest slide with the same heading
+func matchSlide(previous, reloaded *models.Presentation, index int) int {
+	slide, err := previous.GetSlide(index)
+	if err != nil {
+		return index
+	}
+
+	title := slide.Title()
+	if title == "" {
+		return index
+	}
+
+	best := -1
+	for i, candidate := range reloaded.Slides {
+		if candidate.Title() != title {
+			continue
+		}
+
+		if best == -1 || abs(i-index) < abs(best-index) {
+			best = i
+		}
+	}
+
+	if best == -1 {
+		return index
+	}
+	return best
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// includedFiles lists the files besides the deck that its slides are built
+// from: cover templates, a glamour style file and images
+func includedFiles(presentation *models.Presentation, glamourStyle string) []string {
+	dir := filepath.Dir(presentation.FilePath)
+
+	var files []string
+	for _, path := range []string{presentation.TitleTemplate, presentation.ClosingTemplate} {
+		if path == "" {
+			continue
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		files = append(files, path)
+	}
+
+	if glamourStyle != "" && !theme.IsStyleName(glamourStyle) {
+		files = append(files, glamourStyle)
+	}
+
+	for _, slide := range presentation.Slides {
+		files = append(files, display.ImagePaths(slide.RawContent, dir)...)
+	}
+
+	return files
+}
+
+// watchIncludes has watch mode reload when the deck or a file it includes
+// changes, and stops watching files it no longer includes
+func watchIncludes(w *watch.Watcher, presentation *models.Presentation, glamourStyle string) {
+	if w == nil {
+		return
+	}
+	w.SetPaths(append([]string{presentation.FilePath}, includedFiles(presentation, glamourStyle)...)...)
+}
+
+func (a *App) reload() {
+	presentation, err := reparse(a.presentation)
+	var themeConfig models.ThemeConfig
+	if err == nil {
+		themeConfig, err = theme.DeckTheme(a.configTheme, presentation)
+	}
+	if err != nil {
+		// ? Keep presenting the last good version and report the problem
+		a.reloadErr = err
+		return
+	}
+	if a.renderer != nil {
+		if err := a.renderer.SetLayout(presentation.Layout()); err != nil {
+			a.reloadErr = err
+			return
+		}
+	}
+	a.reloadErr = nil
+
+	index := matchSlide(a.presentation, presentation, a.navigator.CurrentIndex())
+
+	// ? Blocks may have moved or changed, so their output no longer applies
+	a.resetRuns()
+	a.closeTerminal()
+
+	a.presentation = presentation
+	a.plan = timer.PlanFor(presentation)
+	a.navigator.SetPresentation(presentation, index)
+	a.broadcast()
+	a.overview.cursor = min(a.overview.cursor, presentation.SlideCount()-1)
+	if a.search.active() {
+		a.search.matches = search.Find(presentation, a.search.pattern)
+	}
+
+	// ? A palette picked while presenting outlasts the reload
+	if a.palette != "" {
+		themeConfig.Palette = a.palette
+	}
+	if err := a.applyTheme(themeConfig); err != nil {
+		a.reloadErr = err
+	}
+	watchIncludes(a.watcher, presentation, a.config.Theme.GlamourStyle)
+
+	if a.renderer != nil {
+		a.renderer.ClearCache(presentation)
+	}
+}
+
+// applyTheme switches to the theme of a reloaded deck. The renderer is only
+// rebuilt when the glamour style changed, as it keeps decoded images.
+func (a *App) applyTheme(themeConfig models.ThemeConfig) error {
+	style := a.config.Theme.GlamourStyle
+
+	a.config.Theme = themeConfig
+	a.theme = theme.NewManager(&a.config.Theme)
+
+	if a.renderer == nil {
+		return nil
+	}
+	if a.config.Theme.GlamourStyle != style {
+		return a.newRenderer()
+	}
+	a.renderer.SetColorScheme(a.theme.GetColorScheme())
+	return nil
+}
+
+// renderReloadError describes why the deck failed to reload in a box shown
+// over the slide, with long parse errors wrapped rather than cut
+func (a *App) renderReloadError() string {
+	width := min(a.width-4, 80)
+
+	var content strings.Builder
+	content.WriteString(a.theme.ErrorStyle().Render("⚠ Reload failed"))
+	content.WriteString("\n\n")
+	content.WriteString(a.reloadErr.Error())
+	content.WriteString("\n\n")
+	content.WriteString(a.theme.HelpStyle().Render("Showing the last version that loaded until the file is fixed"))
+
+	return a.theme.BorderStyle().
+		BorderForeground(a.theme.GetColorScheme().Error).
+		Padding(0, 1).
+		Width(max(width-2, 1)).
+		Render(content.String())
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/watch"
+)
+
+func TestReload(t *testing.T) {
+	a := newTestApp(t, "# One\n\n---\n\n# Two\n\n---\n\n# Three", 80, 24)
+	press(a, "l", "l")
+
+	t.Run("Keeps the slide", func(t *testing.T) {
+		writeDeck(t, a.presentation.FilePath, "# Intro\n\n---\n\n# One\n\n---\n\n# Two\n\n---\n\n# Three")
+		a.Update(fileChangedMsg{})
+
+		if a.reloadErr != nil {
+			t.Fatalf("Expected no reload error, got %v", a.reloadErr)
+		}
+		if got := a.navigator.CurrentIndex(); got != 3 {
+			t.Errorf("Expected to stay on Three at index 3, got %d", got)
+		}
+	})
+
+	t.Run("Keeps the picked palette", func(t *testing.T) {
+		press(a, "c")
+		picked := a.theme.GetPalette()
+
+		writeDeck(t, a.presentation.FilePath, "---\npalette: default\n---\n\n# One\n\n---\n\n# Two")
+		a.Update(fileChangedMsg{})
+
+		if got := a.theme.GetPalette(); got != picked {
+			t.Errorf("Expected palette %q, got %q", picked, got)
+		}
+	})
+
+	t.Run("Reports parse errors", func(t *testing.T) {
+		writeDeck(t, a.presentation.FilePath, "")
+		a.Update(fileChangedMsg{})
+
+		if a.reloadErr == nil {
+			t.Error("Expected a reload error for an empty deck")
+		}
+		if got := a.presentation.SlideCount(); got != 2 {
+			t.Errorf("Expected the last good deck with 2 slides, got %d", got)
+		}
+	})
+}
+
+func TestWatchIncludes(t *testing.T) {
+	dir := t.TempDir()
+	deck := filepath.Join(dir, "slides.md")
+	image := filepath.Join(dir, "image.png")
+	writeDeck(t, deck, "# One\n\n![Logo](image.png)")
+	writeDeck(t, image, "png")
+
+	a := newTestApp(t, "# One", 80, 24)
+	a.presentation.FilePath = deck
+	a.reload()
+
+	watcher := watch.New()
+	watchIncludes(watcher, a.presentation, a.config.Theme.GlamourStyle)
+	watcher.Start()
+	defer watcher.Close()
+
+	expectChange := func(path, content string) {
+		t.Helper()
+		writeDeck(t, path, content)
+
+		select {
+		case <-watcher.Events():
+		case <-time.After(3 * time.Second):
+			t.Errorf("Expected a change event for %s, timed out", filepath.Base(path))
+		}
+	}
+
+	expectChange(image, "another png")
+	expectChange(deck, "# One\n\n![Logo](image.png)\n\nMore")
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"github.com/Kosha-Nirman/slate/src/navigation"
+	"github.com/Kosha-Nirman/slate/src/remote"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// * remoteCommandMsg carries a navigation command from a presenter console
+type remoteCommandMsg remote.Message
+
+// * remoteStateMsg carries the audience position to a presenter console
+type remoteStateMsg remote.Message
+
+// * remoteErrMsg reports that the connection to the audience view is gone
+type remoteErrMsg struct {
+	err error
+}
+
+func waitForCommand(commands <-chan remote.Message) tea.Cmd {
+	return func() tea.Msg {
+		return remoteCommandMsg(<-commands)
+	}
+}
+
+func waitForState(client *remote.Client) tea.Cmd {
+	return func() tea.Msg {
+		msg, err := client.Receive()
+		if err != nil {
+			return remoteErrMsg{err: err}
+		}
+		return remoteStateMsg(msg)
+	}
+}
+
+func applyCommand(nav *navigation.Navigator, msg remote.Message) {
+	switch msg.Command {
+	case remote.CommandNext:
+		nav.Next()
+	case remote.CommandPrevious:
+		nav.Previous()
+	case remote.CommandFirst:
+		nav.First()
+	case remote.CommandLast:
+		nav.Last()
+	case remote.CommandBack:
+		nav.Back()
+	case remote.CommandGoTo:
+		_ = nav.GoTo(msg.Slide)
+	}
+}
+
+func (a *App) broadcast() {
+	if a.server == nil {
+		return
+	}
+
+	position := a.navigator.Position()
+	a.server.Broadcast(remote.Message{
+		Slide:    position.Slide,
+		Fragment: position.Fragment,
+		Elapsed:  a.timer.Elapsed(),
+		Paused:   a.timer.Paused(),
+		Plan:     a.plan,
+	})
+}
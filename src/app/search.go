@@ -0,0 +1,162 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/Kosha-Nirman/slate/src/navigation"
+	"github.com/Kosha-Nirman/slate/src/search"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// * searchState holds the last submitted search
+type searchState struct {
+	query   search.Query
+	pattern *regexp.Regexp
+	// matches are the indexes of the slides containing the pattern
+	matches []int
+}
+
+func (s searchState) active() bool {
+	return s.pattern != nil
+}
+
+func (a *App) searchLabel() string {
+	if a.search.query.Regex {
+		return "regex /"
+	}
+	return "/"
+}
+
+func (a *App) openSearch() {
+	a.prompt.open(a.searchLabel())
+	a.promptFor = promptSearch
+}
+
+func (a *App) setHighlight(re *regexp.Regexp) {
+	if a.renderer != nil {
+		a.renderer.SetHighlight(re)
+	}
+}
+
+func (a *App) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// ? Ctrl+R switches between literal and regex matching
+	if msg.Type == tea.KeyCtrlR {
+		a.search.query.Regex = !a.search.query.Regex
+		a.prompt.label = a.searchLabel()
+		a.previewSearch()
+		return a, nil
+	}
+
+	switch a.prompt.handleKey(msg) {
+	case promptCancelled:
+		a.prompt.close()
+		a.setHighlight(a.search.pattern)
+	case promptSubmitted:
+		a.submitSearch()
+	default:
+		a.previewSearch()
+	}
+
+	return a, nil
+}
+
+// previewSearch highlights matches on the current slide while typing
+func (a *App) previewSearch() {
+	query := search.Query{Text: a.prompt.value(), Regex: a.search.query.Regex}
+
+	re, err := query.Compile()
+	if err != nil {
+		a.setHighlight(nil)
+		return
+	}
+	a.setHighlight(re)
+}
+
+func (a *App) submitSearch() {
+	query := search.Query{Text: a.prompt.value(), Regex: a.search.query.Regex}
+
+	re, err := query.Compile()
+	if err != nil {
+		a.prompt.fail(err)
+		return
+	}
+
+	matches := search.Find(a.presentation, re)
+	if len(matches) == 0 {
+		a.prompt.fail(fmt.Errorf("no matches for %q", query.Text))
+		return
+	}
+
+	a.search = searchState{query: query, pattern: re, matches: matches}
+	a.setHighlight(re)
+	a.prompt.close()
+
+	// * Start from the current slide when it matches
+	a.goToMatch(search.Next(matches, a.navigator.CurrentIndex()-1))
+}
+
+// cycleSearch moves to the next or previous slide with a match
+func (a *App) cycleSearch(forward bool) {
+	current := a.navigator.CurrentIndex()
+
+	target := search.Previous(a.search.matches, current)
+	if forward {
+		target = search.Next(a.search.matches, current)
+	}
+
+	if target < 0 {
+		a.notice = fmt.Sprintf("No matches for %q", a.search.query.Text)
+		return
+	}
+	a.goToMatch(target)
+}
+
+// goToMatch moves to a slide with a match, revealing the fragment that
+// holds it. The match is scrolled into view once the slide is in place.
+func (a *App) goToMatch(index int) {
+	slide, err := a.navigator.GetSlideAt(index)
+	if err != nil {
+		return
+	}
+
+	fragment := search.Fragment(slide, a.search.pattern)
+	if index == a.navigator.CurrentIndex() {
+		fragment = max(fragment, a.navigator.CurrentFragment())
+	}
+	_ = a.navigator.SetPosition(navigation.Position{Slide: index, Fragment: fragment})
+	a.revealMatch = true
+}
+
+// matchScroll is the scroll position that shows the first match on the
+// current slide
+func (a *App) matchScroll() int {
+	if a.renderer == nil || !a.search.active() {
+		return a.scroll
+	}
+
+	frame, err := a.frame(a.navigator.Position(), 0)
+	if err != nil {
+		return a.scroll
+	}
+	scroll, err := a.renderer.MatchScroll(frame, a.search.pattern)
+	if err != nil {
+		return a.scroll
+	}
+	return scroll
+}
+
+func (a *App) clearSearch() {
+	a.search = searchState{query: search.Query{Regex: a.search.query.Regex}}
+	a.setHighlight(nil)
+}
+
+// searchStatus describes the position among the matching slides
+func (a *App) searchStatus() string {
+	position := slices.Index(a.search.matches, a.navigator.CurrentIndex())
+	if position < 0 {
+		return fmt.Sprintf("/%s -/%d", a.search.query.Text, len(a.search.matches))
+	}
+	return fmt.Sprintf("/%s %d/%d", a.search.query.Text, position+1, len(a.search.matches))
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/navigation"
+)
+
+const searchDeck = `# Alpha
+
+find me
+
+---
+
+# Beta
+
+nothing here
+
+---
+
+# Gamma
+
+intro
+
+<!-- @pause -->
+
+find me too
+
+---
+
+# Delta
+
+find`
+
+func TestSearchSubmit(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    int
+		query    string
+		expected navigation.Position
+	}{
+		{"Current slide matches", 0, "find", navigation.Position{Slide: 0, Fragment: 0}},
+		{"Next slide with a match", 1, "find", navigation.Position{Slide: 2, Fragment: 1}},
+		{"Wraps to the start", 3, "me", navigation.Position{Slide: 0, Fragment: 0}},
+		{"Title", 0, "delta", navigation.Position{Slide: 3, Fragment: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t, searchDeck, 80, 24)
+			_ = a.navigator.GoTo(tt.start)
+
+			press(a, "/", tt.query, "enter")
+
+			if a.prompt.active {
+				t.Fatalf("Expected the prompt to close, got error %q", a.prompt.err)
+			}
+			if got := a.navigator.Position(); got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("No matches keeps the prompt open", func(t *testing.T) {
+		a := newTestApp(t, searchDeck, 80, 24)
+		press(a, "/", "missing", "enter")
+
+		if !a.prompt.active {
+			t.Fatal("Expected the prompt to stay open")
+		}
+		if !strings.Contains(a.prompt.err, "no matches") {
+			t.Errorf("Expected a no matches error, got %q", a.prompt.err)
+		}
+		if a.search.active() {
+			t.Error("Expected no search to be active")
+		}
+	})
+
+	t.Run("Escape clears the search", func(t *testing.T) {
+		a := newTestApp(t, searchDeck, 80, 24)
+		press(a, "/", "find", "enter")
+
+		_, cmd := a.Update(keyMsg("esc"))
+		if isQuit(cmd) {
+			t.Error("Expected escape to clear the search, not quit")
+		}
+		if a.search.active() {
+			t.Error("Expected the search to be cleared")
+		}
+	})
+}
+
+func TestSearchCycle(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []string
+		expected navigation.Position
+	}{
+		{"Next reveals the fragment", []string{"n"}, navigation.Position{Slide: 2, Fragment: 1}},
+		{"Next again", []string{"n", "n"}, navigation.Position{Slide: 3, Fragment: 0}},
+		{"Next wraps around", []string{"n", "n", "n"}, navigation.Position{Slide: 0, Fragment: 0}},
+		{"Previous wraps around", []string{"N"}, navigation.Position{Slide: 3, Fragment: 0}},
+		{"Previous reveals the fragment", []string{"N", "N"}, navigation.Position{Slide: 2, Fragment: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestApp(t, searchDeck, 80, 24)
+			press(a, "/", "find", "enter")
+			press(a, tt.keys...)
+
+			if got := a.navigator.Position(); got != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("Status counts the matches", func(t *testing.T) {
+		a := newTestApp(t, searchDeck, 80, 24)
+		press(a, "/", "find", "enter", "n")
+
+		if got := a.searchStatus(); got != "/find 2/3" {
+			t.Errorf("Expected %q, got %q", "/find 2/3", got)
+		}
+
+		// ? The first h hides the fragment, the second moves to Beta, which has no match
+		press(a, "h", "h")
+		if got := a.searchStatus(); got != "/find -/3" {
+			t.Errorf("Expected %q, got %q", "/find -/3", got)
+		}
+	})
+
+	t.Run("Revealed fragments stay revealed", func(t *testing.T) {
+		a := newTestApp(t, searchDeck, 80, 24)
+		_ = a.navigator.SetPosition(navigation.Position{Slide: 2, Fragment: 1})
+
+		press(a, "/", "intro", "enter")
+		if got := a.navigator.Position(); got != (navigation.Position{Slide: 2, Fragment: 1}) {
+			t.Errorf("Expected to stay at slide 2 fragment 1, got %+v", got)
+		}
+	})
+
+	t.Run("n without a search does nothing", func(t *testing.T) {
+		a := newTestApp(t, searchDeck, 80, 24)
+		press(a, "n")
+
+		if got := a.navigator.CurrentIndex(); got != 0 {
+			t.Errorf("Expected slide index 0, got %d", got)
+		}
+	})
+}
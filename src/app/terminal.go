@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Kosha-Nirman/slate/src/terminal"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// * terminalMsg signals new output on the slide terminal
+type terminalMsg struct {
+	term *terminal.Terminal
+}
+
+// * terminalExitMsg signals that the program in the slide terminal exited
+type terminalExitMsg struct {
+	term *terminal.Terminal
+}
+
+func waitForTerminal(term *terminal.Terminal) tea.Cmd {
+	return func() tea.Msg {
+		if _, ok := <-term.Updates(); !ok {
+			return terminalExitMsg{term: term}
+		}
+		return terminalMsg{term: term}
+	}
+}
+
+// focusTerminal sends keys to the terminal of the current slide, starting
+// it first when it is not running
+func (a *App) focusTerminal() tea.Cmd {
+	slide, err := a.navigator.CurrentSlide()
+	if err != nil || !slide.Metadata.Terminal {
+		a.notice = "No terminal on this slide"
+		return nil
+	}
+
+	if a.term != nil && !a.termExited {
+		a.termFocused = true
+		return nil
+	}
+
+	// ? A terminal runs what the deck asks, so it needs agreeing to like code
+	if !a.execAllowed {
+		program := "a shell"
+		if slide.Metadata.TerminalCommand != "" {
+			program = fmt.Sprintf("%q", slide.Metadata.TerminalCommand)
+		}
+		a.confirming = &confirmation{
+			question: fmt.Sprintf("Start %s from this deck on your machine?", program),
+			accept:   a.startTerminal,
+		}
+		return nil
+	}
+
+	return a.startTerminal()
+}
+
+func (a *App) startTerminal() tea.Cmd {
+	slide, err := a.navigator.CurrentSlide()
+	if err != nil || a.renderer == nil {
+		return nil
+	}
+
+	a.closeTerminal()
+
+	width, height := a.renderer.TerminalSize(slide, a.navigator.CurrentFragment())
+	term, err := terminal.Start(slide.Metadata.TerminalCommand, filepath.Dir(a.presentation.FilePath), width, height)
+	if err != nil {
+		a.notice = err.Error()
+		return nil
+	}
+
+	a.term = term
+	a.termSlide = a.navigator.CurrentIndex()
+	a.termFocused = true
+	a.termExited = false
+
+	return waitForTerminal(term)
+}
+
+// closeTerminal stops the slide terminal and hands the keys back to the
+// slides
+func (a *App) closeTerminal() {
+	if a.term != nil {
+		a.term.Close()
+		a.term = nil
+	}
+	a.termFocused = false
+}
+
+func (a *App) resizeTerminal() {
+	if a.term == nil || a.renderer == nil {
+		return
+	}
+
+	slide, err := a.navigator.GetSlideAt(a.termSlide)
+	if err != nil {
+		return
+	}
+	a.term.Resize(a.renderer.TerminalSize(slide, a.navigator.CurrentFragment()))
+}
+
+func (a *App) handleTerminalMsg(msg terminalMsg) tea.Cmd {
+	// ? Output of a terminal closed since is no longer shown
+	if msg.term != a.term {
+		return nil
+	}
+	return waitForTerminal(msg.term)
+}
+
+func (a *App) handleTerminalExit(msg terminalExitMsg) {
+	if msg.term != a.term {
+		return
+	}
+
+	a.termExited = true
+	a.termFocused = false
+	a.notice = "The terminal exited, press ctrl+t to start it again"
+}
+
+func (a *App) handleTerminalKey(msg tea.KeyMsg) tea.Cmd {
+	if msg.String() == "ctrl+t" {
+		a.termFocused = false
+		return nil
+	}
+
+	if input := keyInput(msg); input != nil {
+		a.term.Write(input)
+	}
+	return nil
+}
+
+// * Escape sequences terminals send for keys without a character
+var keySequences = map[tea.KeyType]string{
+	tea.KeyUp:       "\x1b[A",
+	tea.KeyDown:     "\x1b[B",
+	tea.KeyRight:    "\x1b[C",
+	tea.KeyLeft:     "\x1b[D",
+	tea.KeyHome:     "\x1b[H",
+	tea.KeyEnd:      "\x1b[F",
+	tea.KeyPgUp:     "\x1b[5~",
+	tea.KeyPgDown:   "\x1b[6~",
+	tea.KeyInsert:   "\x1b[2~",
+	tea.KeyDelete:   "\x1b[3~",
+	tea.KeyShiftTab: "\x1b[Z",
+	tea.KeySpace:    " ",
+}
+
+// keyInput returns the bytes a terminal sends for a key press
+func keyInput(msg tea.KeyMsg) []byte {
+	var input string
+	switch {
+	case msg.Type == tea.KeyRunes:
+		input = string(msg.Runes)
+	case msg.Type >= 0:
+		// ? Control keys, enter, tab, backspace and escape are sent as is
+		input = string(rune(msg.Type))
+	default:
+		sequence, ok := keySequences[msg.Type]
+		if !ok {
+			return nil
+		}
+		input = sequence
+	}
+
+	if msg.Alt {
+		input = "\x1b" + input
+	}
+	return []byte(input)
+}
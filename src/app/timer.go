@@ -0,0 +1,71 @@
+package app
+
+import (
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/Kosha-Nirman/slate/src/timer"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// timerStatus shows the time left and the pace for the footer, turning
+// yellow and then red as the talk runs out of time. Without a deck duration
+// it only shows the elapsed time while paused.
+func (a *App) timerStatus() string {
+	return describeTimer(a.timer.Elapsed(), a.timer.Paused(), a.plan, a.navigator.CurrentIndex(),
+		a.config.Timer, a.theme)
+}
+
+// needsTick reports whether anything on screen moves with the clock: a
+// header or footer showing the time, or a running talk timer
+func (a *App) needsTick() bool {
+	if a.renderer != nil && a.renderer.LayoutTicks() {
+		return true
+	}
+	return !a.plan.IsZero() && !a.timer.Paused()
+}
+
+// keepTicking schedules the next clock tick when one is needed and none is
+// scheduled yet
+func (a *App) keepTicking() tea.Cmd {
+	if a.ticking || !a.needsTick() {
+		return nil
+	}
+	a.ticking = true
+	return tick()
+}
+
+// describeTimer puts the talk timer into words for the footer of the
+// audience view and the presenter console
+func describeTimer(elapsed time.Duration, paused bool, plan timer.Plan, slide int,
+	thresholds models.TimerConfig, themeManager *theme.Manager) string {
+	if plan.IsZero() && !paused {
+		return ""
+	}
+
+	var status string
+	if plan.IsZero() {
+		status = "⏱ " + timer.Format(elapsed)
+	} else {
+		remaining := plan.Remaining(elapsed)
+		if remaining < 0 {
+			status = "⏱ " + timer.Format(remaining) + " over"
+		} else {
+			status = "⏱ " + timer.Format(remaining) + " left"
+		}
+		status += " · " + timer.DescribePace(plan.Pace(elapsed, slide))
+	}
+
+	if paused {
+		status = "⏸ " + status
+	}
+
+	switch plan.Level(elapsed, thresholds.Warning, thresholds.Critical) {
+	case timer.LevelWarning:
+		return themeManager.WarningStyle().Render(status)
+	case timer.LevelCritical, timer.LevelOver:
+		return themeManager.ErrorStyle().Render(status)
+	}
+	return status
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/Kosha-Nirman/slate/src/navigation"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	transitionDuration = 300 * time.Millisecond
+	transitionFrame    = time.Second / 30
+)
+
+// * transitionFrameMsg advances the running transition by one frame
+type transitionFrameMsg struct {
+	// id tells ticks of an interrupted transition apart from the current one
+	id int
+}
+
+// * transition is an animated change between two slides
+type transition struct {
+	kind    display.Transition
+	from    string
+	started time.Time
+	id      int
+	running bool
+}
+
+func (t *transition) progress() float64 {
+	return float64(time.Since(t.started)) / float64(transitionDuration)
+}
+
+func nextTransitionFrame(id int) tea.Cmd {
+	return tea.Tick(transitionFrame, func(time.Time) tea.Msg {
+		return transitionFrameMsg{id: id}
+	})
+}
+
+// transitionFor picks the transition into the current slide, falling back
+// to the deck default from the front matter
+func (a *App) transitionFor(backward bool) display.Transition {
+	name := a.presentation.Transition
+	if slide, err := a.navigator.CurrentSlide(); err == nil && slide.Metadata.Transition != "" {
+		name = slide.Metadata.Transition
+	}
+
+	kind, _ := display.ParseTransition(name)
+	if backward {
+		return kind.Reverse()
+	}
+	return kind
+}
+
+// startTransition animates from the slide at the previous position to the
+// current one
+func (a *App) startTransition(previous navigation.Position, scroll int) tea.Cmd {
+	if a.config.Presentation.DisableAnimations || a.renderer == nil {
+		return nil
+	}
+
+	kind := a.transitionFor(previous.Slide > a.navigator.CurrentIndex())
+	if kind == display.TransitionNone {
+		return nil
+	}
+
+	from, err := a.renderScreen(previous, scroll)
+	if err != nil {
+		return nil
+	}
+
+	a.transition = transition{
+		kind:    kind,
+		from:    from,
+		started: time.Now(),
+		id:      a.transition.id + 1,
+		running: true,
+	}
+
+	return nextTransitionFrame(a.transition.id)
+}
+
+func (a *App) handleTransitionFrame(msg transitionFrameMsg) tea.Cmd {
+	if !a.transition.running || msg.id != a.transition.id {
+		return nil
+	}
+
+	if a.transition.progress() >= 1 {
+		a.stopTransition()
+		return nil
+	}
+
+	return nextTransitionFrame(msg.id)
+}
+
+func (a *App) stopTransition() {
+	a.transition.running = false
+	a.transition.from = ""
+}
+
+// renderScreen renders the slide area for a position in the deck
+func (a *App) renderScreen(position navigation.Position, scroll int) (string, error) {
+	frame, err := a.frame(position, scroll)
+	if err != nil {
+		return "", err
+	}
+	return a.renderer.RenderFrame(frame)
+}
+
+// frame describes the screen at a position in the deck
+func (a *App) frame(position navigation.Position, scroll int) (display.Frame, error) {
+	slide, err := a.navigator.GetSlideAt(position.Slide)
+	if err != nil {
+		return display.Frame{}, err
+	}
+
+	frame := display.Frame{
+		Slide:    slide,
+		Fragment: position.Fragment,
+		Current:  position.Slide,
+		Total:    a.navigator.TotalSlides(),
+		Scroll:   scroll,
+		Outputs:  a.outputs(position.Slide),
+		Deck:     a.presentation,
+		Elapsed:  a.timer.Elapsed(),
+		Plan:     a.plan,
+	}
+	if a.term != nil && position.Slide == a.termSlide {
+		frame.Terminal = a.term.View(a.termFocused)
+		frame.TerminalFocused = a.termFocused
+	}
+
+	return frame, nil
+}
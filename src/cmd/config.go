@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"cmp"
 	"fmt"
 	"os"
 
 	"github.com/Kosha-Nirman/slate/src/config"
+	"github.com/Kosha-Nirman/slate/src/theme"
 	"github.com/spf13/cobra"
 )
 
@@ -51,6 +53,7 @@ var configShowCmd = &cobra.Command{
 		fmt.Printf("  Glamour Style: %s\n", cfg.Theme.GlamourStyle)
 		fmt.Printf("  Show Progress: %v\n", cfg.Theme.ShowProgress)
 		fmt.Printf("  Show Slide Number: %v\n", cfg.Theme.ShowSlideNum)
+		fmt.Printf("  Palette: %s\n", cmp.Or(cfg.Theme.Palette, theme.DefaultPalette))
 
 		fmt.Printf("\nPresentation:\n")
 		fmt.Printf("  Word Wrap: %d\n", cfg.Presentation.WordWrap)
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/config"
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/export"
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput  string
+	exportNotes   bool
+	exportPerPage int
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a presentation to another format",
+	Long:  "Export a markdown presentation so it can be shared without slate.",
+}
+
+var exportHTMLCmd = &cobra.Command{
+	Use:   "html [file]",
+	Short: "Export a presentation to a standalone HTML file",
+	Long: `Export a presentation to a single self-contained HTML file.
+
+The file embeds its styles and scripts so it works offline. Use the arrow
+keys to navigate and press n to show speaker notes.
+
+Example:
+  slate export html slides.md
+  slate export html slides.md -o talk.html`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		presentation, cfg := loadForExport(args[0])
+
+		// * Use the theme the deck asks for, as the terminal view does
+		themeConfig, err := theme.DeckTheme(cfg.Theme, presentation)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		themeManager := theme.NewManager(&themeConfig)
+		codeStyle := "github"
+		if themeManager.IsDark() {
+			codeStyle = "monokai"
+		}
+
+		writeExport(args[0], ".html", func(file *os.File) error {
+			return export.HTML(file, presentation, export.HTMLOptions{
+				Colors:    themeManager.GetColorScheme(),
+				CodeStyle: codeStyle,
+			})
+		})
+	},
+}
+
+var exportPDFCmd = &cobra.Command{
+	Use:   "pdf [file]",
+	Short: "Export a presentation to PDF handouts",
+	Long: `Export a presentation to a PDF with one slide per page.
+
+Use --notes to print speaker notes below each slide, or --per-page to
+print several slides on each page. Title, author and date from the
+frontmatter appear in the page footer.
+
+Example:
+  slate export pdf slides.md
+  slate export pdf slides.md --notes -o handout.pdf
+  slate export pdf slides.md --per-page 6`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if exportNotes && exportPerPage > 1 {
+			fmt.Fprintln(os.Stderr, "Error: --notes cannot be combined with --per-page")
+			os.Exit(1)
+		}
+
+		presentation, _ := loadForExport(args[0])
+
+		// * Handouts are printed on white paper, so always use the light palette
+		themeManager := theme.NewManager(&models.ThemeConfig{Mode: theme.ModeLight})
+
+		writeExport(args[0], ".pdf", func(file *os.File) error {
+			return export.PDF(file, presentation, export.PDFOptions{
+				Colors:    themeManager.GetColorScheme(),
+				CodeStyle: "github",
+				Notes:     exportNotes,
+				PerPage:   exportPerPage,
+			})
+		})
+	},
+}
+
+func loadForExport(filePath string) (*models.Presentation, *models.Config) {
+	cfg, err := config.New().Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to load config: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if err := data.ValidateFile(filePath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	presentation, err := data.New(filePath).Parse()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to parse presentation: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	presentation.Config = cfg
+	return presentation, cfg
+}
+
+func writeExport(filePath, extension string, write func(file *os.File) error) {
+	// * Default to the deck name with the export extension
+	output := exportOutput
+	if output == "" {
+		output = strings.TrimSuffix(filePath, filepath.Ext(filePath)) + extension
+	}
+
+	file, err := os.Create(filepath.Clean(output))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create %s: %s\n", output, err.Error())
+		os.Exit(1)
+	}
+
+	if err := write(file); err != nil {
+		_ = file.Close()
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if err := file.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write %s: %s\n", output, err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("Exported presentation to: %s\n", output)
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportHTMLCmd)
+	exportCmd.AddCommand(exportPDFCmd)
+
+	exportCmd.PersistentFlags().StringVarP(&exportOutput, "output", "o", "", "Output file path")
+	exportPDFCmd.Flags().BoolVar(&exportNotes, "notes", false, "Print speaker notes below each slide")
+	exportPDFCmd.Flags().IntVar(&exportPerPage, "per-page", 1, "Number of slides per page")
+}
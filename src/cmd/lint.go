@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Kosha-Nirman/slate/src/config"
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/lint"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintFormat    string
+	lintMaxWidth  int
+	lintMaxHeight int
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [file]",
+	Short: "Check a presentation for problems",
+	Long: `Check a presentation for problems and report them as file:line:column.
+
+Checks for invalid front matter, unknown metadata keys, empty slides,
+unclosed code fences, broken relative links and images, slides too large
+for the screen, duplicate slide titles, invalid slide colors, text too
+low in contrast to read, talk or slide durations that cannot be read,
+column layouts that are left open or wider than the slide, unknown
+align, justify or big heading values and terminal slides on systems
+other than Linux.
+
+Exits with status 1 when any error is found. Use --format json for
+machine readable output.
+
+Example:
+  slate lint slides.md
+  slate lint slides.md --max-width 100 --max-height 30
+  slate lint slides.md --format json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if lintFormat != "text" && lintFormat != "json" {
+			fmt.Fprintf(os.Stderr, "Error: unknown format %q, use text or json\n", lintFormat)
+			os.Exit(1)
+		}
+
+		cfg, err := config.New().Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to load config: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		if err := data.ValidateFile(args[0]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		// * Default to the configured word wrap width
+		maxWidth := lintMaxWidth
+		if maxWidth == 0 {
+			maxWidth = cfg.Presentation.WordWrap
+		}
+
+		issues, err := lint.File(args[0], lint.Options{
+			MaxWidth:  maxWidth,
+			MaxHeight: lintMaxHeight,
+			Config:    cfg,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		if lintFormat == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(issues); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+				os.Exit(1)
+			}
+		} else {
+			printIssues(issues)
+		}
+
+		if lint.HasErrors(issues) {
+			os.Exit(1)
+		}
+	},
+}
+
+func printIssues(issues []lint.Issue) {
+	if len(issues) == 0 {
+		fmt.Println("No problems found")
+		return
+	}
+
+	errors := 0
+	for _, issue := range issues {
+		fmt.Println(issue)
+		if issue.Severity == lint.SeverityError {
+			errors++
+		}
+	}
+
+	fmt.Printf("\n%d errors, %d warnings\n", errors, len(issues)-errors)
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVar(&lintFormat, "format", "text", "Output format: text or json")
+	lintCmd.Flags().IntVar(&lintMaxWidth, "max-width", 0, "Widest a slide may render (default: configured word wrap)")
+	lintCmd.Flags().IntVar(&lintMaxHeight, "max-height", 20, "Most lines a slide may render, 0 to skip")
+}
@@ -8,6 +8,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	presenterMode bool
+	watchMode     bool
+	noAnimations  bool
+	allowExec     bool
+)
+
 var presentCmd = &cobra.Command{
 	Use:   "present [file]",
 	Short: "Present a markdown file",
@@ -16,13 +23,32 @@ var presentCmd = &cobra.Command{
 The markdown file should use horizontal rules (---) to separate slides.
 You can also include YAML frontmatter for presentation metadata.
 
+Use --presenter to let a presenter console follow along from a second
+terminal with 'slate presenter slides.md'. Use --watch to reload the
+slides whenever the file changes while you edit it. Use --no-animations
+to switch slides without transitions, for example over slow SSH sessions.
+
+Code blocks marked to run, such as ` + "```go run" + `, run when you press x.
+Slides with <!-- @terminal --> host a shell, started with ctrl+t. Slate asks
+before running code or starting a terminal from a deck the first time,
+unless the deck is on the exec allow list in the configuration or
+--allow-exec is given. Terminal slides are only supported on Linux;
+elsewhere they show their content without a terminal.
+
 Example:
-  slate present slides.md`,
+  slate present slides.md
+  slate present --watch slides.md
+  slate present --presenter slides.md`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		filepath := args[0]
 
-		if err := app.Run(filepath); err != nil {
+		if err := app.Run(filepath, app.Options{
+			Presenter:    presenterMode,
+			Watch:        watchMode,
+			NoAnimations: noAnimations,
+			AllowExec:    allowExec,
+		}); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
 			os.Exit(1)
 		}
@@ -32,4 +58,9 @@ Example:
 
 func init() {
 	rootCmd.AddCommand(presentCmd)
+
+	presentCmd.Flags().BoolVar(&presenterMode, "presenter", false, "Serve the presentation to a presenter console")
+	presentCmd.Flags().BoolVar(&watchMode, "watch", false, "Reload the presentation when the file changes")
+	presentCmd.Flags().BoolVar(&noAnimations, "no-animations", false, "Switch slides without transitions")
+	presentCmd.Flags().BoolVar(&allowExec, "allow-exec", false, "Run code blocks without asking first")
 }
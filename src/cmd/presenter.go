@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Kosha-Nirman/slate/src/app"
+	"github.com/spf13/cobra"
+)
+
+var (
+	presenterWatch bool
+)
+
+var presenterCmd = &cobra.Command{
+	Use:   "presenter [file]",
+	Short: "Open the presenter console for a running presentation",
+	Long: `Open the presenter console for a presentation started with --presenter.
+
+The console shows the current slide, a preview of the next step, speaker
+notes, the elapsed time and the clock. It stays in sync with the audience
+view over a local socket, and navigating from either side moves both.
+
+Example:
+  slate present --presenter slides.md   # first terminal
+  slate presenter slides.md             # second terminal`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		filepath := args[0]
+
+		if err := app.RunPresenter(filepath, app.Options{Watch: presenterWatch}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(presenterCmd)
+
+	presenterCmd.Flags().BoolVar(&presenterWatch, "watch", false, "Reload notes and previews when the file changes")
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/config"
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// * Slide rendered by `slate themes preview`, touching most of what a style colors
+const sampleSlide = `# Quarterly Review
+
+Revenue grew **18%**, with *most* of it from [new customers](https://example.com).
+
+## Highlights
+
+- Shipped the ` + "`billing`" + ` service
+- Cut page load time in half
+
+> Measure twice, cut once.
+
+` + "```go" + `
+func main() {
+    // Print a greeting
+    fmt.Println("Hello, slate!", 42)
+}
+` + "```" + `
+
+| Quarter | Revenue |
+|---------|---------|
+| Q1      | $1.2M   |
+| Q2      | $1.4M   |
+`
+
+var themesCmd = &cobra.Command{
+	Use:   "themes",
+	Short: "List and preview slide styles and palettes",
+	Long: `List and preview the glamour styles slides can be rendered with, and the
+palettes of the UI chrome.
+
+Pick a style with glamourStyle in the configuration or the front matter of
+a deck, either by name or as a path to a JSON style file. Pick a palette
+with palette, and override single colors under colors.`,
+}
+
+var themesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the bundled styles",
+	Long:  "List the glamour styles slate knows by name. The configured style is marked with *.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.New().Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to load config: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		// * Without a configured style, slate picks dark or light as it presents
+		configured := theme.NewManager(&cfg.Theme).GetGlamourStyle()
+
+		for _, style := range theme.Styles {
+			marker := " "
+			if style.Name == configured {
+				marker = "*"
+			}
+			fmt.Printf("%s %-20s %s\n", marker, style.Name, style.Description)
+		}
+
+		// ? A custom style file is configured rather than a bundled style
+		if !theme.IsStyleName(configured) {
+			fmt.Printf("* %-20s %s\n", configured, "custom style file")
+		}
+	},
+}
+
+var themesPalettesCmd = &cobra.Command{
+	Use:   "palettes",
+	Short: "List the color palettes of the UI chrome",
+	Long: `List the palettes the progress bar, header, footer, help and errors can be
+drawn in, with a swatch of their colors. The configured palette is marked
+with *. Palettes defined under palettes in the configuration are listed
+after the bundled ones. Press c while presenting to switch palettes.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.New().Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to load config: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		manager := theme.NewManager(&cfg.Theme)
+		active := manager.GetPalette()
+
+		for _, name := range theme.PaletteNames(cfg.Theme.Palettes) {
+			description := "defined in the configuration"
+			if palette, ok := theme.FindPalette(name); ok {
+				description = palette.Description
+			}
+
+			marker := " "
+			if name == active {
+				marker = "*"
+			}
+
+			// ? Switching only fails for unknown names, and these come from the list
+			_ = manager.SetPalette(name)
+			fmt.Printf("%s %-15s %s  %s\n", marker, name, paletteSwatch(manager.GetColorScheme()), description)
+		}
+	},
+}
+
+// paletteSwatch draws a block in each color of a scheme
+func paletteSwatch(scheme theme.ColorScheme) string {
+	var swatch strings.Builder
+	for _, color := range []lipgloss.Color{
+		scheme.Primary, scheme.Secondary, scheme.Accent, scheme.Muted, scheme.Border,
+		scheme.ProgressBar, scheme.Success, scheme.Warning, scheme.Error,
+	} {
+		swatch.WriteString(lipgloss.NewStyle().Foreground(color).Render("██"))
+	}
+	return swatch.String()
+}
+
+var themesPreviewCmd = &cobra.Command{
+	Use:   "preview [name]",
+	Short: "Render a sample slide in a style",
+	Long: `Render a sample slide in the given style, or in every bundled style when
+no name is given. The name may also be a path to a JSON style file, which
+is checked and reported when it is not a valid style.
+
+Example:
+  slate themes preview
+  slate themes preview projector
+  slate themes preview ./styles/talk.json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.New().Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to load config: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		scheme := theme.NewManager(&cfg.Theme).GetColorScheme()
+
+		styles := theme.Styles
+		if len(args) == 1 {
+			styles = []theme.StyleInfo{{Name: args[0], Description: "custom style file"}}
+			for _, style := range theme.Styles {
+				if style.Name == args[0] {
+					styles = []theme.StyleInfo{style}
+				}
+			}
+		}
+
+		for i, style := range styles {
+			preview, err := previewStyle(*cfg, scheme, style.Name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+				os.Exit(1)
+			}
+
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s  %s\n", color.New(color.Bold).Sprint(style.Name), style.Description)
+			fmt.Println(preview)
+		}
+	},
+}
+
+// previewStyle renders the sample slide the way slate would present it in
+// the given style, with the chrome in scheme
+func previewStyle(cfg models.Config, scheme theme.ColorScheme, style string) (string, error) {
+	if err := theme.ValidateStyle(style); err != nil {
+		return "", err
+	}
+	cfg.Theme.GlamourStyle = style
+
+	deck, err := data.ParseFromString(sampleSlide, "preview.md")
+	if err != nil {
+		return "", err
+	}
+
+	width := cmp.Or(cfg.Presentation.WordWrap, 80) + 2*(cfg.Presentation.Margin+cfg.Presentation.Padding)
+	renderer, err := display.New(&cfg, scheme, width, 40)
+	if err != nil {
+		return "", err
+	}
+
+	return renderer.RenderSlide(deck.Slides[0])
+}
+
+func init() {
+	rootCmd.AddCommand(themesCmd)
+	themesCmd.AddCommand(themesListCmd)
+	themesCmd.AddCommand(themesPalettesCmd)
+	themesCmd.AddCommand(themesPreviewCmd)
+}
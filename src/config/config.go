@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
 	"gopkg.in/yaml.v3"
 )
 
@@ -76,6 +78,11 @@ func ValidateConfig(config *models.Config) error {
 		}
 	}
 
+	// * Validate palette and colors
+	if err := theme.ValidateColors(config.Theme); err != nil {
+		return err
+	}
+
 	// * Validate presentation settings
 	if config.Presentation.WordWrap < 0 {
 		return fmt.Errorf("word wrap must be non-negative")
@@ -89,6 +96,27 @@ func ValidateConfig(config *models.Config) error {
 		return fmt.Errorf("padding must be non-negative")
 	}
 
+	if config.Presentation.Images != "" {
+		validImages := map[string]bool{"auto": true, "kitty": true, "iterm2": true, "sixel": true, "blocks": true, "off": true}
+		if !validImages[config.Presentation.Images] {
+			return fmt.Errorf("invalid images setting: %s (must be auto, kitty, iterm2, sixel, blocks, or off)", config.Presentation.Images)
+		}
+	}
+
+	if config.Presentation.Align != "" {
+		validAligns := map[string]bool{"top": true, "center": true, "bottom": true}
+		if !validAligns[config.Presentation.Align] {
+			return fmt.Errorf("invalid align: %s (must be top, center, or bottom)", config.Presentation.Align)
+		}
+	}
+
+	if config.Presentation.Justify != "" {
+		validJustifies := map[string]bool{"left": true, "center": true, "right": true}
+		if !validJustifies[config.Presentation.Justify] {
+			return fmt.Errorf("invalid justify: %s (must be left, center, or right)", config.Presentation.Justify)
+		}
+	}
+
 	// * Validate keybindings
 	if len(config.Keybindings.Next) == 0 {
 		return fmt.Errorf("next keybinding must have at least one key")
@@ -203,13 +231,38 @@ func (l *Loader) LoadFromFile(path string) (*models.Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse config YAML: %w", err)
+	}
+
+	// ? Keys are case-insensitive, as in front matter, so glamourStyle and
+	// glamourstyle both load
+	lowerKeys(&node)
+
 	var config models.Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	if node.Kind == 0 {
+		return &config, nil
+	}
+	if err := node.Decode(&config); err != nil {
 		return nil, fmt.Errorf("failed to parse config YAML: %w", err)
 	}
 
 	return &config, nil
 }
+
+// lowerKeys lowercases the keys of every mapping in a YAML document, which
+// is how the config fields are named
+func lowerKeys(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			node.Content[i].Value = strings.ToLower(node.Content[i].Value)
+		}
+	}
+	for _, child := range node.Content {
+		lowerKeys(child)
+	}
+}
 func (l *Loader) Load() (*models.Config, error) {
 	// Start with default config
 	config := models.NewDefaultConfig()
@@ -232,5 +285,15 @@ func (l *Loader) Load() (*models.Config, error) {
 	// Merge file config into default config
 	config.Merge(fileConfig)
 
+	// ? Style files are found relative to the config file, and checked now
+	// rather than when the first slide renders
+	config.Theme.GlamourStyle = theme.ResolveStylePath(config.Theme.GlamourStyle, filepath.Dir(configPath))
+	if err := theme.ValidateStyle(config.Theme.GlamourStyle); err != nil {
+		return nil, fmt.Errorf("invalid glamourStyle in %s: %w", configPath, err)
+	}
+	if err := theme.ValidateColors(config.Theme); err != nil {
+		return nil, fmt.Errorf("invalid colors in %s: %w", configPath, err)
+	}
+
 	return config, nil
 }
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a config file to a temporary directory
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), configFileName)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Expected to write the config, got %v", err)
+	}
+	return path
+}
+
+func TestLoadFromFileKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"Camel case", "theme:\n  glamourStyle: dracula\n  showProgress: true\npresentation:\n  wordWrap: 100\n  disableAnimations: true\n"},
+		{"Lower case", "theme:\n  glamourstyle: dracula\n  showprogress: true\npresentation:\n  wordwrap: 100\n  disableanimations: true\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := New().LoadFromFile(writeConfig(t, tt.content))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if config.Theme.GlamourStyle != "dracula" {
+				t.Errorf("Expected glamour style 'dracula', got '%s'", config.Theme.GlamourStyle)
+			}
+			if !config.Theme.ShowProgress {
+				t.Error("Expected ShowProgress to be true")
+			}
+			if config.Presentation.WordWrap != 100 {
+				t.Errorf("Expected WordWrap 100, got %d", config.Presentation.WordWrap)
+			}
+			if !config.Presentation.DisableAnimations {
+				t.Error("Expected DisableAnimations to be true")
+			}
+		})
+	}
+}
+
+func TestLoadFromFileEmpty(t *testing.T) {
+	config, err := New().LoadFromFile(writeConfig(t, ""))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if config.Presentation.WordWrap != 0 {
+		t.Errorf("Expected an empty config, got %+v", config)
+	}
+}
+
+func TestLoadColors(t *testing.T) {
+	t.Chdir(filepath.Dir(writeConfig(t, `theme:
+  palette: Brand
+  colors:
+    progressBar: "#ff6600"
+  palettes:
+    Brand:
+      primary: "#003366"
+      progressbar: "214"
+`)))
+
+	config, err := New().Load()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if config.Theme.Palette != "brand" {
+		t.Errorf("Expected palette 'brand', got '%s'", config.Theme.Palette)
+	}
+	if config.Theme.Colors.ProgressBar != "#ff6600" {
+		t.Errorf("Expected progress bar '#ff6600', got '%s'", config.Theme.Colors.ProgressBar)
+	}
+	brand := config.Theme.Palettes["brand"]
+	if brand.Primary != "#003366" || brand.ProgressBar != "214" {
+		t.Errorf("Expected palette 'brand' to load, got %+v", brand)
+	}
+}
+
+func TestLoadColorErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"Unknown palette", "theme:\n  palette: oceans\n", `unknown palette "oceans"`},
+		{"Invalid color", "theme:\n  colors:\n    progressBar: blurple\n", "invalid progressBar color"},
+		{"Invalid palette color", "theme:\n  palettes:\n    brand:\n      muted: \"#12345\"\n", "palette brand"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(filepath.Dir(writeConfig(t, tt.content)))
+
+			_, err := New().Load()
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected an error containing %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
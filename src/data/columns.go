@@ -0,0 +1,99 @@
+package data
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+var (
+	// Match the line opening a row of columns
+	columnsOpenRegex = regexp.MustCompile(`^:{3,}\s*columns\s*$`)
+	// Match the line opening a column and capture its width in percent
+	columnOpenRegex = regexp.MustCompile(`^:{3,}\s*column(?:\s+(\d+)\s*%?)?\s*$`)
+	// Match the line closing a column or a row of columns
+	divCloseRegex = regexp.MustCompile(`^:{3,}\s*$`)
+)
+
+// ParseColumns finds the rows of columns in slide content, written as
+//
+//	::: columns
+//	::: column 40%
+//	Left
+//	:::
+//	::: column
+//	Right
+//	:::
+//	:::
+//
+// A column also ends where the next one opens. Markers inside code blocks
+// and comments are left alone, and a row still open at the end of the
+// content runs to its last line, as happens when a pause cuts through it.
+func ParseColumns(content string) []models.ColumnGroup {
+	if !strings.Contains(content, ":::") {
+		return nil
+	}
+
+	var groups []models.ColumnGroup
+	var group *models.ColumnGroup
+	var column *models.Column
+	var lines []string
+
+	closeColumn := func() {
+		if column != nil {
+			column.Content = strings.Trim(strings.Join(lines, "\n"), "\n")
+			group.Columns = append(group.Columns, *column)
+		}
+		column, lines = nil, nil
+	}
+
+	last := 0
+	for _, token := range Tokenize(content) {
+		// ? Only markdown text can hold markers
+		markers := token.Kind == TokenText || token.Kind == TokenBlank
+
+		for i, line := range token.Lines {
+			number := token.Line + i
+			last = number
+			trimmed := strings.TrimSpace(line)
+
+			switch {
+			case !markers:
+			case group == nil:
+				if columnsOpenRegex.MatchString(trimmed) {
+					group = &models.ColumnGroup{Line: number}
+				}
+				continue
+			case columnOpenRegex.MatchString(trimmed):
+				closeColumn()
+				width, _ := strconv.Atoi(columnOpenRegex.FindStringSubmatch(trimmed)[1])
+				column = &models.Column{Width: width}
+				continue
+			case divCloseRegex.MatchString(trimmed):
+				if column != nil {
+					closeColumn()
+					continue
+				}
+				group.EndLine, group.Closed = number, true
+				groups = append(groups, *group)
+				group = nil
+				continue
+			}
+
+			// ? Text between columns belongs to no column and is dropped
+			if column != nil {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	if group != nil {
+		closeColumn()
+		group.EndLine = last
+		groups = append(groups, *group)
+	}
+
+	return groups
+}
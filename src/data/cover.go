@@ -0,0 +1,132 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+// * Default layouts of the generated slides, in markdown. Decks can brand
+// them with titleTemplate and closingTemplate files in the front matter.
+const (
+	defaultTitleTemplate = `# {{ .Title }}
+{{ with .Subtitle }}
+*{{ . }}*
+{{ end }}{{ with .Authors }}
+**{{ join . ", " }}**
+{{ end }}{{ with .Affiliation }}
+{{ . }}
+{{ end }}{{ with .Event }}
+{{ . }}
+{{ end }}{{ with .Date }}
+{{ . }}
+{{ end }}`
+
+	defaultClosingTemplate = `# Thank You
+{{ with .Title }}
+{{ . }}
+{{ end }}{{ with .Author }}
+**{{ . }}**
+{{ end }}`
+)
+
+// * coverData is what cover templates can use
+type coverData struct {
+	Title       string
+	Subtitle    string
+	Author      string
+	Authors     []string
+	Affiliation string
+	Event       string
+	// Date is the front matter date as written, empty when unset
+	Date string
+	// Slides is the number of slides written in the deck
+	Slides int
+}
+
+var coverFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// addGeneratedSlides adds the cover and closing slides the front matter
+// asks for and renumbers the slides. Templates may set slide metadata such
+// as colors like any other slide.
+func (p *Parser) addGeneratedSlides(presentation *models.Presentation, metadata map[string]string) error {
+	if !presentation.TitleSlide && !presentation.ClosingSlide {
+		return nil
+	}
+
+	data := coverData{
+		Title:       presentation.Title,
+		Subtitle:    presentation.Subtitle,
+		Author:      presentation.Author,
+		Authors:     presentation.Authors,
+		Affiliation: presentation.Affiliation,
+		Event:       presentation.Event,
+		Date:        metadata["date"],
+		Slides:      presentation.SlideCount(),
+	}
+	dir := filepath.Dir(presentation.FilePath)
+
+	if presentation.TitleSlide {
+		content, err := renderCover("title", presentation.TitleTemplate, defaultTitleTemplate, dir, data)
+		if err != nil {
+			return err
+		}
+		slide := p.parseSlide(0, content)
+		slide.Kind = models.SlideTitle
+		presentation.Slides = append([]*models.Slide{slide}, presentation.Slides...)
+	}
+
+	if presentation.ClosingSlide {
+		content, err := renderCover("closing", presentation.ClosingTemplate, defaultClosingTemplate, dir, data)
+		if err != nil {
+			return err
+		}
+		slide := p.parseSlide(0, content)
+		slide.Kind = models.SlideClosing
+		presentation.AddSlide(slide)
+	}
+
+	for i, slide := range presentation.Slides {
+		slide.Index = i
+	}
+
+	return nil
+}
+
+// renderCover fills in a cover template, read from path relative to the deck
+// when one is given
+func renderCover(name, path, fallback, dir string, data coverData) (string, error) {
+	source := fallback
+	if path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s template: %w", name, err)
+		}
+		source = string(content)
+	}
+
+	tmpl, err := template.New(name).Funcs(coverFuncs).Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
+	}
+
+	return out.String(), nil
+}
@@ -0,0 +1,78 @@
+package data
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+var (
+	// Match an exec marker comment that makes the next code block runnable
+	execMarkerRegex = regexp.MustCompile(`^<!--\s*@exec\s*-->$`)
+)
+
+// findCodeBlocks returns the fenced code blocks marked to run, either with
+// run or exec after the language, as in ```go run, or by an exec marker
+// comment right before the fence
+func findCodeBlocks(content string) []models.CodeBlock {
+	var blocks []models.CodeBlock
+	marked := false
+
+	for _, token := range Tokenize(content) {
+		switch token.Kind {
+		case TokenBlank:
+			continue
+		case TokenComment:
+			if execMarkerRegex.MatchString(strings.TrimSpace(token.Text())) {
+				marked = true
+				continue
+			}
+		case TokenFence:
+			if block, ok := parseCodeBlock(token, marked); ok {
+				blocks = append(blocks, block)
+			}
+		}
+		marked = false
+	}
+
+	return blocks
+}
+
+// parseCodeBlock reads the language and code of a fence, reporting whether
+// it is runnable
+func parseCodeBlock(token Token, marked bool) (models.CodeBlock, bool) {
+	match := fenceOpenRegex.FindStringSubmatch(token.Lines[0])
+	if match == nil || !token.Closed {
+		return models.CodeBlock{}, false
+	}
+
+	info := strings.Fields(match[3])
+	if len(info) == 0 {
+		return models.CodeBlock{}, false
+	}
+
+	runnable := marked
+	for _, word := range info[1:] {
+		if word == "run" || word == "exec" {
+			runnable = true
+		}
+	}
+	if !runnable {
+		return models.CodeBlock{}, false
+	}
+
+	// ? Code inside an indented fence loses the indentation of the fence
+	indent := len(match[1])
+	lines := token.Lines[1 : len(token.Lines)-1]
+	code := make([]string, len(lines))
+	for i, line := range lines {
+		code[i] = strings.TrimPrefix(line, strings.Repeat(" ", min(indent, indentWidth(line))))
+	}
+
+	return models.CodeBlock{
+		Language: strings.ToLower(info[0]),
+		Code:     strings.Join(code, "\n"),
+		Source:   token.Text(),
+	}, true
+}
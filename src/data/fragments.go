@@ -0,0 +1,68 @@
+package data
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Match a pause marker comment that ends the current fragment
+	pauseMarkerRegex = regexp.MustCompile(`^<!--\s*@pause\s*-->$`)
+)
+
+// splitIntoFragments cuts slide content at pause markers and, for incremental
+// slides, before each top-level list item. It returns nil when the slide
+// reveals in a single step.
+func (p *Parser) splitIntoFragments(content string, incremental bool) []string {
+	fragments := make([]string, 0)
+	current := make([]string, 0)
+
+	hasContent := func() bool {
+		return strings.TrimSpace(strings.Join(current, "\n")) != ""
+	}
+
+	flush := func() {
+		if hasContent() {
+			fragments = append(fragments, strings.Join(current, "\n"))
+		}
+		current = make([]string, 0)
+	}
+
+	for _, token := range Tokenize(content) {
+		// ? Pause markers only count outside code blocks
+		if IsPauseMarker(token) {
+			flush()
+			continue
+		}
+
+		if incremental && IsTopLevelListItem(token) && hasContent() {
+			flush()
+		}
+
+		current = append(current, token.Lines...)
+	}
+	flush()
+
+	if len(fragments) < 2 {
+		return nil
+	}
+
+	return fragments
+}
+
+// IsPauseMarker reports whether token is a pause marker, which ends the
+// fragment before it
+func IsPauseMarker(token Token) bool {
+	return token.Kind == TokenComment && pauseMarkerRegex.MatchString(strings.TrimSpace(token.Text()))
+}
+
+// IsTopLevelListItem reports whether token opens a list item that is not
+// nested, which starts a fragment of its own on incremental slides
+func IsTopLevelListItem(token Token) bool {
+	if token.Kind != TokenText {
+		return false
+	}
+
+	line := token.Lines[0]
+	return indentWidth(line) == 0 && listItemRegex.MatchString(line)
+}
@@ -1,13 +1,15 @@
 package data
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/Kosha-Nirman/slate/src/models"
 	"gopkg.in/yaml.v3"
@@ -18,8 +20,13 @@ const slideSeparator = "---"
 var (
 	// Match YAML FrontMatter at the start of the file
 	frontMatterRegex = regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---\s*\n`)
-	// Match slide-specific metadata comments
-	slideMetadataRegex = regexp.MustCompile(`<!--\s*@(\w+):\s*(.+?)\s*-->`)
+	// Match slide-specific metadata comments, either `@key: value` on one
+	// line or `@key` followed by a value on the lines below
+	slideMetadataRegex = regexp.MustCompile(`(?s)<!--\s*@(\w+)(?::[ \t]*|[ \t]*\n)(.*?)\s*-->`)
+	// Match a terminal marker without a command
+	terminalMarkerRegex = regexp.MustCompile(`<!--\s*@terminal\s*-->`)
+	// Match a big text marker without a value
+	bigTextMarkerRegex = regexp.MustCompile(`(?i)<!--\s*@bigtext\s*-->`)
 )
 
 type Parser struct {
@@ -32,39 +39,127 @@ func New(filePath string) *Parser {
 	}
 }
 
-func (p *Parser) extractFrontMatter(content string) (string, map[string]string) {
+// * MetadataKeys lists the <!-- @key: value --> comments slides understand
+var MetadataKeys = []string{
+	"notes", "transition", "background", "foreground", "accent", "incremental", "header", "footer", "time", "terminal", "align", "justify", "bigtext",
+}
+
+// IsMetadataKey reports whether key is a known metadata key or directive
+func IsMetadataKey(key string) bool {
+	key = strings.ToLower(key)
+	return key == "pause" || key == "exec" || slices.Contains(MetadataKeys, key)
+}
+
+// SplitFrontMatter separates the YAML front matter from the slides. It
+// returns the YAML source, the remaining content and the number of lines the
+// block took up, or ok false when the deck has no front matter.
+func SplitFrontMatter(content string) (source, body string, lines int, ok bool) {
 	matches := frontMatterRegex.FindStringSubmatch(content)
 	if len(matches) < 2 {
-		return content, make(map[string]string)
+		return "", content, 0, false
 	}
 
-	// * Parse YAML FrontMatter
+	return matches[1], content[len(matches[0]):], strings.Count(matches[0], "\n"), true
+}
+
+// DecodeFrontMatter parses front matter YAML into string values
+func DecodeFrontMatter(source string) (map[string]string, error) {
 	metadata := make(map[string]any)
-	if err := yaml.Unmarshal([]byte(matches[1]), &metadata); err != nil {
-		return content, make(map[string]string)
+	if err := yaml.Unmarshal([]byte(source), &metadata); err != nil {
+		return nil, err
 	}
 
-	// * Convert string to map
+	// * Convert string to map, keys are case-insensitive
 	result := make(map[string]string)
+	flattenFrontMatter(result, "", metadata)
+
+	return result, nil
+}
+
+// flattenFrontMatter adds the values of metadata to result as strings.
+// Nested maps such as colors: add keys like colors.primary.
+func flattenFrontMatter(result map[string]string, prefix string, metadata map[string]any) {
 	for k, v := range metadata {
-		result[k] = fmt.Sprintf("%v", v)
+		k = prefix + strings.ToLower(k)
+
+		switch v := v.(type) {
+		case time.Time:
+			// ? YAML decodes unquoted dates as timestamps
+			result[k] = v.Format("2006-01-02")
+		case []any:
+			// ? Lists such as several authors become one item per line
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprintf("%v", item))
+			}
+			result[k] = strings.Join(items, "\n")
+		case map[string]any:
+			flattenFrontMatter(result, k+".", v)
+		default:
+			result[k] = fmt.Sprintf("%v", v)
+		}
 	}
+}
 
-	// * Remove FrontMatter from content
-	remainingContent := frontMatterRegex.ReplaceAllString(content, "")
-	return remainingContent, result
+func (p *Parser) extractFrontMatter(content string) (string, map[string]string) {
+	source, body, _, ok := SplitFrontMatter(content)
+	if !ok {
+		return content, make(map[string]string)
+	}
+
+	// ? Invalid YAML is reported by `slate lint`, present the deck as is
+	metadata, err := DecodeFrontMatter(source)
+	if err != nil {
+		return content, make(map[string]string)
+	}
+
+	return body, metadata
 }
 
-func (p *Parser) splitIntoSlides(content string) []string {
-	// * Split by horizontal rule (---)
-	parts := strings.Split(content, "\n"+slideSeparator+"\n")
+// * Section is the raw source of one slide and the line it starts on
+type Section struct {
+	Content string
+	Line    int
+}
+
+// Sections splits content into slides, keeping the line each one starts on
+func Sections(content string) []Section {
+	sections := make([]Section, 0)
+	current := make([]string, 0)
+	start := 1
 
-	slides := make([]string, 0)
-	for _, part := range parts {
-		trimmed := strings.TrimSpace(part)
-		if trimmed != "" {
-			slides = append(slides, trimmed)
+	flush := func() {
+		if trimmed := strings.TrimSpace(strings.Join(current, "\n")); trimmed != "" {
+			sections = append(sections, Section{Content: trimmed, Line: start})
 		}
+		current = current[:0]
+	}
+
+	// * Only separators at block level end a slide
+	for _, token := range Tokenize(content) {
+		if token.Kind == TokenSeparator {
+			flush()
+			start = token.Line + 1
+			continue
+		}
+
+		if len(current) == 0 && token.Kind == TokenBlank {
+			start = token.Line + 1
+			continue
+		}
+		current = append(current, token.Lines...)
+	}
+	flush()
+
+	return sections
+}
+
+func (p *Parser) splitIntoSlides(content string) []string {
+	sections := Sections(content)
+
+	slides := make([]string, 0, len(sections))
+	for _, s := range sections {
+		slides = append(slides, s.Content)
 	}
 
 	return slides
@@ -79,22 +174,191 @@ func (p *Parser) extractSlideMetadata(content string) models.SlideMetadata {
 			continue
 		}
 
+		// ? Keys are case-insensitive, values keep their case
 		key := strings.ToLower(match[1])
-		value := strings.ToLower(match[2])
+		value := dedent(match[2])
 
 		switch key {
 		case "notes":
 			metadata.Notes = value
 		case "transition":
-			metadata.Transition = value
+			metadata.Transition = strings.ToLower(value)
 		case "background":
 			metadata.Background = value
+		case "foreground":
+			metadata.Foreground = value
+		case "accent":
+			metadata.Accent = value
+		case "time":
+			metadata.Time = value
+		case "align":
+			metadata.Align = strings.ToLower(value)
+		case "justify":
+			metadata.Justify = strings.ToLower(value)
+		case "bigtext":
+			metadata.BigText = bigTextLevel(value)
+		case "terminal":
+			metadata.Terminal = true
+			metadata.TerminalCommand = value
+		case "header", "footer":
+			hidden := isHidden(value)
+			if key == "header" {
+				metadata.HideHeader = hidden
+			} else {
+				metadata.HideFooter = hidden
+			}
+		case "incremental":
+			value = strings.ToLower(value)
+			metadata.Incremental = value == "true" || value == "yes"
+		default:
+			// * Keep custom keys so other features can read them
+			if metadata.Extra == nil {
+				metadata.Extra = make(map[string]string)
+			}
+			metadata.Extra[key] = value
 		}
 	}
 
+	// ? A terminal needs no command, so the marker may stand alone
+	if terminalMarkerRegex.MatchString(content) {
+		metadata.Terminal = true
+	}
+
+	if bigTextMarkerRegex.MatchString(content) {
+		metadata.BigText = "h1"
+	}
+
 	return metadata
 }
 
+// bigTextLevel reads the value of @bigtext, which turns big headings on
+// for H1 unless it names another level or turns them off
+func bigTextLevel(value string) string {
+	value = strings.ToLower(value)
+	switch {
+	case isHidden(value):
+		return "off"
+	case value == "" || value == "on" || value == "true" || value == "yes":
+		return "h1"
+	}
+	return value
+}
+
+// isHidden reports whether a value such as hide or off turns something off
+func isHidden(value string) bool {
+	switch strings.ToLower(value) {
+	case "hide", "hidden", "none", "off", "false", "no":
+		return true
+	}
+	return false
+}
+
+// dedent trims a multi-line value and removes the indentation its lines
+// share, so notes written inside an indented comment read naturally
+func dedent(value string) string {
+	lines := strings.Split(strings.TrimSpace(value), "\n")
+
+	indent := -1
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		width := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || width < indent {
+			indent = width
+		}
+	}
+
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if i > 0 && indent > 0 && len(line) >= indent {
+			line = line[indent:]
+		}
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// splitNotes separates a `???` or `Note:` speaker notes section from the
+// slide body. Everything after the marker is notes. A `Note:` with text after
+// it only starts notes when it opens the last paragraphs of the slide, so
+// slides can still say "Note: ..." to the audience.
+func splitNotes(content string) (string, string) {
+	tokens := Tokenize(content)
+
+	for index, token := range tokens {
+		if token.Kind != TokenText {
+			continue
+		}
+
+		for i, line := range token.Lines {
+			var notes string
+			switch {
+			case strings.TrimRight(line, " \t") == "???":
+			case strings.TrimRight(line, " \t") == "Note:":
+			case strings.HasPrefix(line, "Note:") && i == 0 && startsTrailingNotes(tokens, index):
+				notes = strings.TrimPrefix(line, "Note:")
+			default:
+				continue
+			}
+
+			lines := strings.Split(content, "\n")
+			start := token.Line - 1 + i
+			notes = strings.Join(append([]string{notes}, lines[start+1:]...), "\n")
+
+			return strings.Join(lines[:start], "\n"), dedent(notes)
+		}
+	}
+
+	return content, ""
+}
+
+// startsTrailingNotes reports whether the paragraph at index follows a blank
+// line and only plain paragraphs come after it, no headings, code or comments
+func startsTrailingNotes(tokens []Token, index int) bool {
+	if index == 0 || tokens[index-1].Kind != TokenBlank {
+		return false
+	}
+
+	for _, token := range tokens[index+1:] {
+		if token.Kind != TokenText && token.Kind != TokenBlank {
+			return false
+		}
+		for _, line := range token.Lines {
+			if strings.HasPrefix(strings.TrimSpace(line), "#") {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (p *Parser) parseSlide(index int, content string) *models.Slide {
+	body, notes := splitNotes(content)
+	slide := models.NewSlide(index, body)
+
+	// * Extract slide-specific metadata
+	slide.Metadata = p.extractSlideMetadata(content)
+
+	// ? A notes section adds to any notes given in a comment
+	if notes != "" {
+		slide.Metadata.Notes = strings.TrimSpace(slide.Metadata.Notes + "\n\n" + notes)
+	}
+
+	// * Split into incremental reveal steps
+	slide.Fragments = p.splitIntoFragments(slide.RawContent, slide.Metadata.Incremental)
+
+	// * Find the code blocks that can run live
+	slide.CodeBlocks = findCodeBlocks(slide.RawContent)
+
+	// * Find the multi-column layouts
+	slide.Columns = ParseColumns(slide.RawContent)
+
+	return slide
+}
+
 func (p *Parser) Parse() (*models.Presentation, error) {
 	content, err := os.ReadFile(p.filePath)
 	if err != nil {
@@ -113,14 +377,18 @@ func (p *Parser) Parse() (*models.Presentation, error) {
 
 	// * Parse each slide
 	for i, slideContent := range slides {
-		slide := models.NewSlide(i, slideContent)
-
-		// * Extract slide-specific metadata
-		slide.Metadata = p.extractSlideMetadata(slideContent)
-
+		slide := p.parseSlide(i, slideContent)
+		// ? Slides without @bigtext follow the deck
+		if slide.Metadata.BigText == "" {
+			slide.Metadata.BigText = presentation.BigHeadings
+		}
 		presentation.AddSlide(slide)
 	}
 
+	if err := p.addGeneratedSlides(presentation, metadata); err != nil {
+		return nil, err
+	}
+
 	return presentation, nil
 }
 
@@ -161,11 +429,18 @@ func ParseFromString(content string, filePath string) (*models.Presentation, err
 
 	// * Parse each slide
 	for i, slideContent := range slides {
-		slide := models.NewSlide(i, slideContent)
-		slide.Metadata = parser.extractSlideMetadata(slideContent)
+		slide := parser.parseSlide(i, slideContent)
+		// ? Slides without @bigtext follow the deck
+		if slide.Metadata.BigText == "" {
+			slide.Metadata.BigText = presentation.BigHeadings
+		}
 		presentation.AddSlide(slide)
 	}
 
+	if err := parser.addGeneratedSlides(presentation, metadata); err != nil {
+		return nil, err
+	}
+
 	return presentation, nil
 }
 
@@ -192,19 +467,24 @@ func CountSlides(filePath string) (int, error) {
 		}
 	}()
 
-	scanner := bufio.NewScanner(file)
-	// * At least one slide
-	count := 1
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.TrimSpace(line) == slideSeparator {
-			count++
-		}
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return 0, err
 	}
 
-	if err := scanner.Err(); err != nil {
-		return 0, err
+	// * Count with the same splitting rules as Parse
+	parser := &Parser{filePath: cleanPath}
+	contentStr, metadata := parser.extractFrontMatter(string(content))
+	count := len(parser.splitIntoSlides(contentStr))
+
+	// ? Count the generated cover and closing slides too
+	presentation := models.NewPresentation(cleanPath)
+	presentation.SetMetadata(metadata)
+	if presentation.TitleSlide {
+		count++
+	}
+	if presentation.ClosingSlide {
+		count++
 	}
 
 	return count, nil
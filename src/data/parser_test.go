@@ -0,0 +1,470 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+func TestSplitIntoSlides(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected int
+	}{
+		{"Plain separators", "# One\n\n---\n\n# Two\n\n---\n\n# Three", 3},
+		{"Backtick fence", "# One\n\n```yaml\na: 1\n---\nb: 2\n```\n\n---\n\n# Two", 2},
+		{"Tilde fence", "# One\n\n~~~\n---\n~~~\n\n---\n\n# Two", 2},
+		{"Longer closing fence", "# One\n\n````\n```\n---\n````\n\n---\n\n# Two", 2},
+		{"HTML comment", "# One\n\n<!--\n---\n-->\n\n---\n\n# Two", 2},
+		{"Indented code", "# One\n\n    a: 1\n    ---\n    b: 2\n\n---\n\n# Two", 2},
+		{"Fence in list item", "- item\n\n      ```\n      ---\n      ```\n\n---\n\n# Two", 2},
+		{"Unclosed fence", "# One\n\n```\n---\n\n# Two", 1},
+		{"Empty slides dropped", "---\n\n# One\n\n---\n\n---\n\n# Two\n", 2},
+		{"Windows line endings", "# One\r\n\r\n---\r\n\r\n# Two", 2},
+	}
+
+	parser := New("test.md")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slides := parser.splitIntoSlides(tt.content)
+			if len(slides) != tt.expected {
+				t.Errorf("Expected %d slides, got %d: %q", tt.expected, len(slides), slides)
+			}
+		})
+	}
+}
+
+func TestSplitKeepsFenceContent(t *testing.T) {
+	content := "# Config\n\n```yaml\nkind: A\n---\nkind: B\n```"
+
+	slides := New("test.md").splitIntoSlides(content)
+	if len(slides) != 1 {
+		t.Fatalf("Expected 1 slide, got %d", len(slides))
+	}
+
+	if !strings.Contains(slides[0], "kind: A\n---\nkind: B") {
+		t.Errorf("Expected fence content to be preserved, got %q", slides[0])
+	}
+}
+
+func TestSplitSectionsLineNumbers(t *testing.T) {
+	content := "# One\n\n---\n\n\n# Two\n---\n# Three"
+
+	sections := Sections(content)
+	expected := []int{1, 6, 8}
+
+	if len(sections) != len(expected) {
+		t.Fatalf("Expected %d sections, got %d", len(expected), len(sections))
+	}
+
+	for i, line := range expected {
+		if sections[i].Line != line {
+			t.Errorf("Expected section %d to start on line %d, got %d", i, line, sections[i].Line)
+		}
+	}
+}
+
+func TestCountSlidesMatchesParse(t *testing.T) {
+	content := `---
+title: Infra Talk
+---
+
+# Manifests
+
+` + "```yaml" + `
+apiVersion: v1
+kind: Namespace
+---
+apiVersion: v1
+kind: Service
+` + "```" + `
+
+---
+
+# Thanks
+`
+
+	path := filepath.Join(t.TempDir(), "deck.md")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write deck: %v", err)
+	}
+
+	presentation, err := New(path).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	count, err := CountSlides(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if presentation.SlideCount() != 2 {
+		t.Errorf("Expected 2 slides, got %d", presentation.SlideCount())
+	}
+
+	if count != presentation.SlideCount() {
+		t.Errorf("Expected CountSlides %d to match Parse %d", count, presentation.SlideCount())
+	}
+}
+
+func TestSplitIntoFragments(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		incremental bool
+		expected    int
+	}{
+		{"No markers", "# Title\n\n- One\n- Two", false, 0},
+		{"Pause markers", "# Title\n\n<!-- @pause -->\n\nBody\n\n<!-- @pause -->\n\nMore", false, 3},
+		{"Marker inside fence", "# Title\n\n```\n<!-- @pause -->\n```", false, 0},
+		{"Trailing marker", "# Title\n\n<!-- @pause -->", false, 0},
+		{"Incremental list", "# Title\n\n- One\n  - Nested\n- Two\n- Three", true, 4},
+		{"Incremental list only", "- One\n- Two", true, 2},
+	}
+
+	parser := New("test.md")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fragments := parser.splitIntoFragments(tt.content, tt.incremental)
+			if len(fragments) != tt.expected {
+				t.Errorf("Expected %d fragments, got %d: %q", tt.expected, len(fragments), fragments)
+			}
+		})
+	}
+}
+
+func TestExtractFrontMatterDate(t *testing.T) {
+	content := "---\ntitle: Talk\ndate: 2025-12-25\n---\n\n# One"
+
+	presentation, err := ParseFromString(content, "test.md")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := presentation.Date.Format("2006-01-02"); got != "2025-12-25" {
+		t.Errorf("Expected date 2025-12-25, got %s", got)
+	}
+}
+
+func TestExtractFrontMatterColors(t *testing.T) {
+	content := "---\ntitle: Talk\npalette: Ocean\ncolors:\n  primary: \"#003366\"\n  progressBar: 214\n---\n\n# One"
+
+	presentation, err := ParseFromString(content, "test.md")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if presentation.Palette != "ocean" {
+		t.Errorf("Expected palette 'ocean', got %q", presentation.Palette)
+	}
+	if presentation.Colors.Primary != "#003366" {
+		t.Errorf("Expected primary '#003366', got %q", presentation.Colors.Primary)
+	}
+	if presentation.Colors.ProgressBar != "214" {
+		t.Errorf("Expected progress bar '214', got %q", presentation.Colors.ProgressBar)
+	}
+}
+
+func TestSlideMetadataKeepsCase(t *testing.T) {
+	content := "# AWS\n\n<!-- @notes: Mention AWS and Kubernetes -->\n<!-- @background: #FFAA00 -->\n<!-- @Accent: Orange -->\n<!-- @Owner: Platform Team -->\n<!-- @Transition: Fade -->"
+
+	presentation, err := ParseFromString(content, "test.md")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	metadata := presentation.Slides[0].Metadata
+
+	if metadata.Notes != "Mention AWS and Kubernetes" {
+		t.Errorf("Expected notes to keep case, got %q", metadata.Notes)
+	}
+	if metadata.Background != "#FFAA00" {
+		t.Errorf("Expected background '#FFAA00', got %q", metadata.Background)
+	}
+	if metadata.Accent != "Orange" {
+		t.Errorf("Expected accent 'Orange', got %q", metadata.Accent)
+	}
+	if metadata.Transition != "fade" {
+		t.Errorf("Expected transition 'fade', got %q", metadata.Transition)
+	}
+	if metadata.Extra["owner"] != "Platform Team" {
+		t.Errorf("Expected custom key in Extra, got %v", metadata.Extra)
+	}
+}
+
+func TestSlideNotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		body     string
+		expected string
+	}{
+		{
+			name:     "Multi-line comment",
+			content:  "# Title\n\n<!-- @notes\n  First point.\n    - detail\n  Second point.\n-->",
+			body:     "# Title\n\n<!-- @notes\n  First point.\n    - detail\n  Second point.\n-->",
+			expected: "First point.\n  - detail\nSecond point.",
+		},
+		{
+			name:     "Question mark section",
+			content:  "# Title\n\nVisible\n\n???\nSay hello.\nThen wave.",
+			body:     "# Title\n\nVisible",
+			expected: "Say hello.\nThen wave.",
+		},
+		{
+			name:     "Note section",
+			content:  "# Title\n\nVisible\n\nNote: Keep it short.\nReally.",
+			body:     "# Title\n\nVisible",
+			expected: "Keep it short.\nReally.",
+		},
+		{
+			name:     "Note marker on its own line",
+			content:  "# Title\n\nVisible\nNote:\nSay hello.",
+			body:     "# Title\n\nVisible",
+			expected: "Say hello.",
+		},
+		{
+			name:     "Content begins with Note",
+			content:  "Note: this API is deprecated\n\n- Use the new one",
+			body:     "Note: this API is deprecated\n\n- Use the new one",
+			expected: "",
+		},
+		{
+			name:     "Note inside a paragraph",
+			content:  "# Title\n\nVisible\nNote: part of the slide",
+			body:     "# Title\n\nVisible\nNote: part of the slide",
+			expected: "",
+		},
+		{
+			name:     "Note followed by slide content",
+			content:  "# Title\n\nNote: read this first\n\n## Details\n\nMore",
+			body:     "# Title\n\nNote: read this first\n\n## Details\n\nMore",
+			expected: "",
+		},
+		{
+			name:     "Note followed by code",
+			content:  "# Title\n\nNote: this runs\n\n```go\nfmt.Println()\n```",
+			body:     "# Title\n\nNote: this runs\n\n```go\nfmt.Println()\n```",
+			expected: "",
+		},
+		{
+			name:     "Marker inside code",
+			content:  "# Title\n\n```\n???\n```",
+			body:     "# Title\n\n```\n???\n```",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			presentation, err := ParseFromString(tt.content, "test.md")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			slide := presentation.Slides[0]
+
+			if slide.RawContent != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, slide.RawContent)
+			}
+			if slide.Metadata.Notes != tt.expected {
+				t.Errorf("Expected notes %q, got %q", tt.expected, slide.Metadata.Notes)
+			}
+		})
+	}
+}
+
+func TestGeneratedSlides(t *testing.T) {
+	dir := t.TempDir()
+	template := "# {{ upper .Title }}\n\n{{ .Event }} · {{ .Slides }} slides"
+	if err := os.WriteFile(filepath.Join(dir, "cover.md"), []byte(template), 0600); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	content := "---\ntitle: Infra Talk\nauthors:\n  - Ada\n  - Alan\nevent: GopherCon\ntitleSlide: true\ntitleTemplate: cover.md\nclosingSlide: true\n---\n\n# One\n\n---\n\n# Two\n"
+	path := filepath.Join(dir, "deck.md")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write deck: %v", err)
+	}
+
+	presentation, err := New(path).Parse()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if presentation.SlideCount() != 4 {
+		t.Fatalf("Expected 4 slides, got %d", presentation.SlideCount())
+	}
+	if presentation.Author != "Ada, Alan" {
+		t.Errorf("Expected authors 'Ada, Alan', got %q", presentation.Author)
+	}
+
+	kinds := []models.SlideKind{models.SlideTitle, models.SlideContent, models.SlideContent, models.SlideClosing}
+	for i, slide := range presentation.Slides {
+		if slide.Kind != kinds[i] || slide.Index != i {
+			t.Errorf("Expected slide %d to be kind %d, got kind %d at index %d", i, kinds[i], slide.Kind, slide.Index)
+		}
+	}
+
+	if cover := presentation.Slides[0].Content(); cover != "# INFRA TALK\n\nGopherCon · 2 slides" {
+		t.Errorf("Expected cover from template, got %q", cover)
+	}
+	if !strings.Contains(presentation.Slides[3].Content(), "Ada, Alan") {
+		t.Errorf("Expected closing slide to name the authors, got %q", presentation.Slides[3].Content())
+	}
+
+	count, err := CountSlides(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != presentation.SlideCount() {
+		t.Errorf("Expected CountSlides %d to match Parse %d", count, presentation.SlideCount())
+	}
+}
+
+func TestCodeBlocks(t *testing.T) {
+	content := "# Demo\n\n```go run\nfmt.Println(1)\n```\n\n```python\nprint(2)\n```\n\n<!-- @exec -->\n\n```Python\nprint(3)\n```\n\n- item\n\n  ```sh exec\n  echo 4\n  ```"
+
+	presentation, err := ParseFromString(content, "test.md")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	blocks := presentation.Slides[0].CodeBlocks
+
+	expected := []struct {
+		language string
+		code     string
+	}{
+		{"go", "fmt.Println(1)"},
+		{"python", "print(3)"},
+		{"sh", "echo 4"},
+	}
+
+	if len(blocks) != len(expected) {
+		t.Fatalf("Expected %d code blocks, got %d: %v", len(expected), len(blocks), blocks)
+	}
+	for i, want := range expected {
+		if blocks[i].Language != want.language || blocks[i].Code != want.code {
+			t.Errorf("Expected block %d to be %s %q, got %s %q", i, want.language, want.code, blocks[i].Language, blocks[i].Code)
+		}
+		if !strings.Contains(content, blocks[i].Source) {
+			t.Errorf("Expected block %d source to be found in the slide, got %q", i, blocks[i].Source)
+		}
+	}
+}
+
+func TestTerminalSlides(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		terminal bool
+		command  string
+	}{
+		{"Marker", "# Shell\n\n<!-- @terminal -->", true, ""},
+		{"Command", "# Top\n\n<!-- @terminal: htop -->", true, "htop"},
+		{"None", "# Plain", false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			presentation, err := ParseFromString(tt.content, "test.md")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			metadata := presentation.Slides[0].Metadata
+			if metadata.Terminal != tt.terminal || metadata.TerminalCommand != tt.command {
+				t.Errorf("Expected terminal %v with %q, got %v with %q", tt.terminal, tt.command, metadata.Terminal, metadata.TerminalCommand)
+			}
+		})
+	}
+}
+
+func TestBigTextSlides(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"Marker", "# Title\n\n<!-- @bigtext -->", "h1"},
+		{"Level", "# Title\n\n<!-- @bigtext: H2 -->", "h2"},
+		{"From deck", "---\nbigHeadings: h1\n---\n# Title", "h1"},
+		{"Off on slide", "---\nbigHeadings: h1\n---\n# Title\n\n<!-- @bigtext: off -->", "off"},
+		{"None", "# Title", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			presentation, err := ParseFromString(tt.content, "test.md")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if bigText := presentation.Slides[0].Metadata.BigText; bigText != tt.expected {
+				t.Errorf("Expected big text %q, got %q", tt.expected, bigText)
+			}
+		})
+	}
+}
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		widths  []int
+		texts   []string
+		closed  bool
+	}{
+		{
+			name:    "Closed columns",
+			content: "# Title\n\n::: columns\n::: column 40%\nLeft\n:::\n::: column\nRight\n:::\n:::",
+			widths:  []int{40, 0},
+			texts:   []string{"Left", "Right"},
+			closed:  true,
+		},
+		{
+			name:    "Column ended by the next one",
+			content: "::: columns\n::: column 30\nA\n\n::: column 70\nB\n:::\n:::",
+			widths:  []int{30, 70},
+			texts:   []string{"A", "B"},
+			closed:  true,
+		},
+		{
+			name:    "Markers inside code",
+			content: "::: columns\n::: column\n```md\n:::\n```\n:::\n:::",
+			widths:  []int{0},
+			texts:   []string{"```md\n:::\n```"},
+			closed:  true,
+		},
+		{
+			name:    "Open at the end",
+			content: "::: columns\n::: column\nOnly",
+			widths:  []int{0},
+			texts:   []string{"Only"},
+			closed:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := ParseColumns(tt.content)
+			if len(groups) != 1 {
+				t.Fatalf("Expected 1 group, got %d", len(groups))
+			}
+			group := groups[0]
+
+			if group.Closed != tt.closed {
+				t.Errorf("Expected closed %v, got %v", tt.closed, group.Closed)
+			}
+			if len(group.Columns) != len(tt.widths) {
+				t.Fatalf("Expected %d columns, got %d: %v", len(tt.widths), len(group.Columns), group.Columns)
+			}
+			for i, column := range group.Columns {
+				if column.Width != tt.widths[i] || column.Content != tt.texts[i] {
+					t.Errorf("Expected column %d to be %d%% %q, got %d%% %q", i, tt.widths[i], tt.texts[i], column.Width, column.Content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package data
+
+import (
+	"regexp"
+	"strings"
+)
+
+// * TokenKind identifies the kind of markdown block a Token covers
+type TokenKind int
+
+const (
+	TokenText TokenKind = iota
+	TokenBlank
+	TokenFence
+	TokenComment
+	TokenIndentedCode
+	TokenSeparator
+)
+
+// * Token is a block-level chunk of markdown source
+type Token struct {
+	Kind TokenKind
+	// Line is the 1-based line number of the first line of the token
+	Line int
+	// Lines holds the raw source lines covered by the token
+	Lines []string
+	// Closed reports whether a fence or comment was terminated
+	Closed bool
+}
+
+var (
+	// Match an opening code fence and capture indentation, marker and info string
+	fenceOpenRegex = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	// Match an ordered or unordered list item marker
+	listItemRegex = regexp.MustCompile(`^\s{0,3}([-+*]|\d{1,9}[.)])(\s|$)`)
+)
+
+// Text returns the raw source covered by the token
+func (t Token) Text() string {
+	return strings.Join(t.Lines, "\n")
+}
+
+// EndLine returns the 1-based line number of the last line of the token
+func (t Token) EndLine() int {
+	return t.Line + len(t.Lines) - 1
+}
+
+type tokenizer struct {
+	lines  []string
+	pos    int
+	tokens []Token
+	inList bool
+}
+
+// Tokenize splits markdown content into block-level tokens so callers can
+// tell slide separators apart from `---` lines inside code or comments
+func Tokenize(content string) []Token {
+	t := &tokenizer{
+		lines: strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n"),
+	}
+
+	for t.pos < len(t.lines) {
+		line := t.lines[t.pos]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			t.emit(TokenBlank, 1, true)
+		case isSeparator(line):
+			t.inList = false
+			t.emit(TokenSeparator, 1, true)
+		case t.startsIndentedCode(line):
+			t.readIndentedCode()
+		case t.startsFence(line):
+			t.readFence()
+		case strings.HasPrefix(strings.TrimSpace(line), "<!--"):
+			t.readComment()
+		default:
+			t.readText(line)
+		}
+	}
+
+	return t.tokens
+}
+
+func (t *tokenizer) emit(kind TokenKind, count int, closed bool) {
+	t.tokens = append(t.tokens, Token{
+		Kind:   kind,
+		Line:   t.pos + 1,
+		Lines:  t.lines[t.pos : t.pos+count],
+		Closed: closed,
+	})
+	t.pos += count
+}
+
+func (t *tokenizer) previousKind() (TokenKind, bool) {
+	if len(t.tokens) == 0 {
+		return TokenBlank, false
+	}
+	return t.tokens[len(t.tokens)-1].Kind, true
+}
+
+func (t *tokenizer) startsIndentedCode(line string) bool {
+	if indentWidth(line) < 4 || t.inList {
+		return false
+	}
+
+	// ? Indented code cannot interrupt a paragraph
+	kind, ok := t.previousKind()
+	return !ok || kind == TokenBlank || kind == TokenSeparator
+}
+
+func (t *tokenizer) startsFence(line string) bool {
+	// ? Fences nested in list items may be indented further than three spaces
+	if t.inList {
+		line = strings.TrimLeft(line, " \t")
+	}
+
+	matches := fenceOpenRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return false
+	}
+
+	// ? Backtick fences cannot carry backticks in their info string
+	return !strings.HasPrefix(matches[2], "`") || !strings.Contains(matches[3], "`")
+}
+
+func (t *tokenizer) readIndentedCode() {
+	end := t.pos + 1
+	for end < len(t.lines) {
+		line := t.lines[end]
+		if strings.TrimSpace(line) != "" && indentWidth(line) < 4 {
+			break
+		}
+		end++
+	}
+
+	// * Trailing blank lines belong to the surrounding document
+	for end > t.pos+1 && strings.TrimSpace(t.lines[end-1]) == "" {
+		end--
+	}
+
+	t.emit(TokenIndentedCode, end-t.pos, true)
+}
+
+func (t *tokenizer) readFence() {
+	marker := fenceOpenRegex.FindStringSubmatch(strings.TrimLeft(t.lines[t.pos], " \t"))[2]
+
+	for end := t.pos + 1; end < len(t.lines); end++ {
+		if isFenceClose(t.lines[end], marker) {
+			t.emit(TokenFence, end-t.pos+1, true)
+			return
+		}
+	}
+
+	// * An unclosed fence runs to the end of the document
+	t.emit(TokenFence, len(t.lines)-t.pos, false)
+}
+
+func (t *tokenizer) readComment() {
+	for end := t.pos; end < len(t.lines); end++ {
+		line := t.lines[end]
+		if end == t.pos {
+			line = line[strings.Index(line, "<!--")+len("<!--"):]
+		}
+
+		if strings.Contains(line, "-->") {
+			t.emit(TokenComment, end-t.pos+1, true)
+			return
+		}
+	}
+
+	t.emit(TokenComment, len(t.lines)-t.pos, false)
+}
+
+func (t *tokenizer) readText(line string) {
+	// * Track list context so nested fences and continuations are recognised
+	if listItemRegex.MatchString(line) {
+		t.inList = true
+	} else if indentWidth(line) == 0 {
+		if kind, ok := t.previousKind(); !ok || kind == TokenBlank {
+			t.inList = false
+		}
+	}
+
+	t.emit(TokenText, 1, true)
+}
+
+func isSeparator(line string) bool {
+	return indentWidth(line) <= 3 && strings.TrimSpace(line) == slideSeparator
+}
+
+func isFenceClose(line, marker string) bool {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < len(marker) {
+		return false
+	}
+
+	return strings.Trim(trimmed, marker[:1]) == ""
+}
+
+func indentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - (width % 4)
+		default:
+			return width
+		}
+	}
+	return width
+}
@@ -0,0 +1,94 @@
+package display
+
+import (
+	"cmp"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+// * Placements slides may ask for, vertically with align and horizontally
+// with justify
+var (
+	Aligns    = []string{"top", "center", "bottom"}
+	Justifies = []string{"left", "center", "right"}
+)
+
+// placement returns where the content of a slide goes, as set by the slide,
+// else by the deck, else in the configuration
+func (r *Renderer) placement(slide *models.Slide, deck *models.Presentation) (string, string) {
+	align, justify := slide.Metadata.Align, slide.Metadata.Justify
+	if deck != nil {
+		align, justify = cmp.Or(align, deck.Align), cmp.Or(justify, deck.Justify)
+	}
+	return cmp.Or(align, r.config.Presentation.Align), cmp.Or(justify, r.config.Presentation.Justify)
+}
+
+// placeBody moves the rendered body within the slide area. The body moves
+// as one block, so lists and code keep their indentation. Bodies taller
+// than the screen stay at the top, where they scroll from.
+func (r *Renderer) placeBody(body, align, justify string) string {
+	if align != "center" && align != "bottom" && justify != "center" && justify != "right" {
+		return body
+	}
+
+	lines := strings.Split(body, "\n")
+
+	// * Measure the visible block, leaving out glamour's margin and padding
+	left, right := -1, 0
+	for _, line := range lines {
+		plain := strings.TrimRight(xansi.Strip(line), " ")
+		if plain == "" {
+			continue
+		}
+		indent := xansi.StringWidth(plain) - xansi.StringWidth(strings.TrimLeft(plain, " "))
+		if left < 0 || indent < left {
+			left = indent
+		}
+		right = max(right, xansi.StringWidth(plain))
+	}
+	if left < 0 {
+		return body
+	}
+
+	width := r.style.GetWidth() - r.style.GetHorizontalPadding()
+	shift := 0
+	switch justify {
+	case "center":
+		shift = (width-(right-left))/2 - left
+	case "right":
+		shift = width - right
+	}
+
+	// ? Padding after the text makes room for the shift
+	if shift > 0 {
+		pad := strings.Repeat(" ", shift)
+		for i, line := range lines {
+			lines[i] = xansi.Truncate(pad+line, width, "")
+		}
+	}
+
+	placed := strings.Join(lines, "\n")
+	if align != "center" && align != "bottom" {
+		return placed
+	}
+
+	blank := func(line string) bool { return strings.TrimSpace(xansi.Strip(line)) == "" }
+	for len(lines) > 0 && blank(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && blank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+
+	free := r.viewportHeight() - len(lines)
+	if free <= 0 {
+		return placed
+	}
+	if align == "center" {
+		free /= 2
+	}
+
+	return strings.Repeat("\n", free) + strings.Join(lines, "\n")
+}
@@ -0,0 +1,50 @@
+package display
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+)
+
+func TestPlaceBody(t *testing.T) {
+	config := models.NewDefaultConfig()
+	config.Presentation.Margin = 0
+	config.Presentation.Padding = 0
+	config.Theme.ShowProgress = false
+
+	r, err := New(config, theme.ColorScheme{}, 20, 6)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	r.Resize(20, 6)
+	height := r.viewportHeight()
+
+	tall := strings.Repeat("line\n", height+2) + "line"
+
+	tests := []struct {
+		name     string
+		body     string
+		align    string
+		justify  string
+		expected string
+	}{
+		{"Top left unchanged", "ab\ncd", "top", "left", "ab\ncd"},
+		{"Centered across", "ab\n  cd", "top", "center", "        ab\n          cd"},
+		{"Right", "ab", "top", "right", strings.Repeat(" ", 18) + "ab"},
+		{"Indent kept", "  ab", "top", "right", strings.Repeat(" ", 16) + "  ab"},
+		{"Centered down", "\nab\ncd\n", "center", "left", strings.Repeat("\n", (height-2)/2) + "ab\ncd"},
+		{"Bottom", "ab", "bottom", "left", strings.Repeat("\n", height-1) + "ab"},
+		{"Taller than the screen", tall, "center", "left", tall},
+		{"Blank", "\n  \n", "center", "center", "\n  \n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.placeBody(tt.body, tt.align, tt.justify); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package display
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/figlet"
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// * Fonts for big headings, largest first. A heading takes the largest one
+// it fits in, on up to bigTextLines lines.
+var bigTextFonts = []string{"block", "small"}
+
+const bigTextLines = 2
+
+var (
+	// Match an ATX heading and capture its level and text
+	headingRegex = regexp.MustCompile(`^ {0,3}(#{1,6})[ \t]+(.*?)(?:[ \t]+#+)?[ \t]*$`)
+	// Match inline links and images, capturing their text
+	inlineLinkRegex = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	// Match the text put in place of a big heading before rendering
+	bigTextTokenRegex = regexp.MustCompile(`slate-bigtext-(\d+)`)
+)
+
+// BigTextLevel returns the deepest heading level a bigtext setting such as
+// h1 draws in large letters, or false when the value is not a level
+func BigTextLevel(value string) (int, bool) {
+	level, err := strconv.Atoi(strings.TrimPrefix(value, "h"))
+	if !strings.HasPrefix(value, "h") || err != nil || level < 1 || level > 6 {
+		return 0, false
+	}
+	return level, true
+}
+
+// replaceBigText puts a token in place of each heading the slide draws in
+// large letters, so the letters can be drawn where glamour renders the
+// token. Headings that fit in no font, even across bigTextLines lines, are
+// left for glamour.
+func (r *Renderer) replaceBigText(slide *models.Slide, content string) (string, [][]string) {
+	level, ok := BigTextLevel(slide.Metadata.BigText)
+	if !ok || !strings.Contains(content, "#") {
+		return content, nil
+	}
+
+	// ? Glamour indents the document by its margin
+	width := r.imageWidth()
+	if margin := r.baseStyle.Document.Margin; margin != nil {
+		width -= int(*margin)
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	var headings [][]string
+
+	for _, token := range data.Tokenize(content) {
+		if token.Kind != data.TokenText {
+			continue
+		}
+		for i, line := range token.Lines {
+			match := headingRegex.FindStringSubmatch(line)
+			if match == nil || len(match[1]) > level {
+				continue
+			}
+			drawn, ok := r.bigText(plainHeading(match[2]), width)
+			if !ok {
+				continue
+			}
+
+			lines[token.Line-1+i] = "\n" + "slate-bigtext-" + strconv.Itoa(len(headings)) + "\n"
+			headings = append(headings, drawn)
+		}
+	}
+
+	return strings.Join(lines, "\n"), headings
+}
+
+// bigText draws text in the largest font it fits width in, wrapping between
+// words when the whole text does not fit on one line
+func (r *Renderer) bigText(text string, width int) ([]string, bool) {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil, false
+	}
+
+	for _, name := range bigTextFonts {
+		font, err := figlet.Load(name)
+		if err != nil {
+			continue
+		}
+
+		var drawn []string
+		lines := 0
+		for start := 0; start < len(words); {
+			// * Take as many words as fit on the line
+			end := len(words)
+			var line []string
+			for ; end > start; end-- {
+				rendered, ok := font.Render(strings.Join(words[start:end], " "))
+				if ok && figlet.Width(rendered) <= width {
+					line = rendered
+					break
+				}
+			}
+			if line == nil {
+				break
+			}
+
+			if lines > 0 {
+				drawn = append(drawn, "")
+			}
+			drawn = append(drawn, line...)
+			lines++
+			start = end
+			if start == len(words) {
+				return drawn, true
+			}
+			if lines == bigTextLines {
+				break
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// drawBigText replaces the lines glamour rendered for big heading tokens
+// with the large letters, in the heading color of the slide
+func (r *Renderer) drawBigText(slide *models.Slide, rendered string, headings [][]string) (string, error) {
+	if len(headings) == 0 {
+		return rendered, nil
+	}
+
+	style := lipgloss.NewStyle().Foreground(r.headingColor(slide)).Bold(true)
+
+	return expandTokens(rendered, bigTextTokenRegex, len(headings), func(n, indent int) ([]string, error) {
+		lines := make([]string, len(headings[n]))
+		for i, line := range headings[n] {
+			if line != "" {
+				line = style.Render(line)
+			}
+			lines[i] = strings.Repeat(" ", indent) + line
+		}
+		return lines, nil
+	})
+}
+
+// headingColor is the color glamour gives the top heading of a slide. Styles
+// that draw it as a colored bar give the color of the bar.
+func (r *Renderer) headingColor(slide *models.Slide) lipgloss.Color {
+	if accent := r.colors(slide).accent; accent != "" {
+		return accent
+	}
+
+	style := r.baseStyle
+	for _, color := range []*string{style.H1.BackgroundColor, style.H1.Color, style.Heading.Color, style.Document.Color} {
+		if color != nil {
+			return lipgloss.Color(*color)
+		}
+	}
+	return ""
+}
+
+// plainHeading drops the markdown from the text of a heading, keeping what
+// would show on screen
+func plainHeading(text string) string {
+	text = inlineLinkRegex.ReplaceAllString(text, "$1")
+	return strings.NewReplacer("**", "", "__", "", "*", "", "`", "", "~~", "").Replace(text)
+}
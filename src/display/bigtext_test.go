@@ -0,0 +1,30 @@
+package display
+
+import "testing"
+
+func TestBigTextLevel(t *testing.T) {
+	tests := []struct {
+		value string
+		level int
+		ok    bool
+	}{
+		{"h1", 1, true},
+		{"h3", 3, true},
+		{"h6", 6, true},
+		{"h0", 0, false},
+		{"h7", 0, false},
+		{"1", 0, false},
+		{"hx", 0, false},
+		{"H1", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			level, ok := BigTextLevel(tt.value)
+			if level != tt.level || ok != tt.ok {
+				t.Errorf("Expected (%d, %v), got (%d, %v)", tt.level, tt.ok, level, ok)
+			}
+		})
+	}
+}
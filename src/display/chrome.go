@@ -0,0 +1,250 @@
+package display
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/timer"
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+// * Footer shown when neither the config nor the deck sets one
+const defaultFooterRight = "{{ .SlideNumber }} / {{ .Total }}"
+
+// * ChromeData is what header and footer templates can use
+type ChromeData struct {
+	Title       string
+	Author      string
+	Event       string
+	Date        string
+	SlideTitle  string
+	SlideNumber int
+	Total       int
+	// Section is the title of the closest slide at or before this one that
+	// opens with a top-level heading
+	Section string
+	Elapsed string
+	Clock   string
+	// Remaining and Pace count down the talk duration and tell whether the
+	// talk is behind its plan, empty when the deck sets no duration
+	Remaining string
+	Pace      string
+}
+
+// * bar is a header or footer with a template per slot, nil when unused
+type bar struct {
+	left, center, right *template.Template
+}
+
+func (b *bar) execute(data ChromeData) (left, center, right string, err error) {
+	slots := []*string{&left, &center, &right}
+	for i, tmpl := range []*template.Template{b.left, b.center, b.right} {
+		if tmpl == nil {
+			continue
+		}
+
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, data); err != nil {
+			return "", "", "", err
+		}
+		// ? Bars are one line high
+		*slots[i] = strings.Join(strings.Fields(out.String()), " ")
+	}
+	return left, center, right, nil
+}
+
+// parseBar compiles the templates of a bar and checks they only use known
+// fields, returning nil when every slot is empty
+func parseBar(name string, slots models.SlotsConfig) (*bar, error) {
+	if slots.IsEmpty() {
+		return nil, nil
+	}
+
+	b := &bar{}
+	for _, slot := range []struct {
+		name   string
+		source string
+		tmpl   **template.Template
+	}{
+		{"left", slots.Left, &b.left},
+		{"center", slots.Center, &b.center},
+		{"right", slots.Right, &b.right},
+	} {
+		if slot.source == "" {
+			continue
+		}
+		tmpl, err := template.New(name + "-" + slot.name).Parse(slot.source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %s template: %w", name, slot.name, err)
+		}
+		*slot.tmpl = tmpl
+	}
+
+	if _, _, _, err := b.execute(ChromeData{}); err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+
+	return b, nil
+}
+
+// ValidateLayout reports header and footer templates that cannot be used
+func ValidateLayout(layout models.LayoutConfig) error {
+	if _, err := parseBar("header", layout.Header); err != nil {
+		return err
+	}
+	_, err := parseBar("footer", layout.Footer)
+	return err
+}
+
+// SetLayout sets the header and footer templates. The slide number stands
+// in for the footer when none is set and the config shows slide numbers.
+func (r *Renderer) SetLayout(layout models.LayoutConfig) error {
+	if layout.Footer.IsEmpty() && r.config.Theme.ShowSlideNum {
+		layout.Footer.Right = defaultFooterRight
+	}
+
+	header, err := parseBar("header", layout.Header)
+	if err != nil {
+		return err
+	}
+	footer, err := parseBar("footer", layout.Footer)
+	if err != nil {
+		return err
+	}
+
+	r.header, r.footer = header, footer
+	r.ticks = usesClock(layout.Header) || usesClock(layout.Footer)
+
+	// * The bars change how much room the slide has
+	r.Resize(r.width, r.height)
+
+	return nil
+}
+
+func usesClock(slots models.SlotsConfig) bool {
+	for _, source := range []string{slots.Left, slots.Center, slots.Right} {
+		for _, field := range []string{".Elapsed", ".Clock", ".Remaining", ".Pace"} {
+			if strings.Contains(source, field) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// LayoutTicks reports whether the header or footer shows a clock and needs
+// redrawing every second
+func (r *Renderer) LayoutTicks() bool {
+	return r.ticks
+}
+
+func chromeData(frame Frame) ChromeData {
+	data := ChromeData{
+		SlideNumber: frame.Current + 1,
+		Total:       frame.Total,
+		Elapsed:     FormatDuration(frame.Elapsed),
+		Clock:       time.Now().Format("15:04"),
+	}
+	if frame.Slide != nil {
+		data.SlideTitle = frame.Slide.Title()
+	}
+	if !frame.Plan.IsZero() {
+		data.Remaining = FormatRemaining(frame.Plan.Remaining(frame.Elapsed))
+		data.Pace = timer.DescribePace(frame.Plan.Pace(frame.Elapsed, frame.Current))
+	}
+
+	deck := frame.Deck
+	if deck == nil {
+		return data
+	}
+
+	data.Title = deck.Title
+	data.Author = deck.Author
+	data.Event = deck.Event
+	if !deck.Date.IsZero() {
+		data.Date = deck.Date.Format("2006-01-02")
+	}
+
+	for i := min(frame.Current, deck.SlideCount()-1); i >= 0; i-- {
+		slide := deck.Slides[i]
+		if title, level := slide.Heading(); level == 1 && !slide.IsGenerated() {
+			data.Section = title
+			break
+		}
+	}
+
+	return data
+}
+
+// renderBar draws a header or footer across the screen, or a blank line
+// when the slide hides it
+func (r *Renderer) renderBar(b *bar, data ChromeData, hidden bool, background lipgloss.Color) (string, error) {
+	inset := r.config.Presentation.Margin
+	width := max(r.width-2*inset, 0)
+
+	style := lipgloss.NewStyle().
+		Foreground(r.scheme.Muted).
+		Width(r.width).
+		Padding(0, inset)
+	if background != "" {
+		style = style.Background(background)
+	}
+
+	if hidden {
+		return style.Render(""), nil
+	}
+
+	left, center, right, err := b.execute(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to render bar: %w", err)
+	}
+
+	return style.Render(layoutBar(width, left, center, right)), nil
+}
+
+// layoutBar places text at the left, center and right of a line, shortening
+// the side slots when they would run into each other
+func layoutBar(width int, left, center, right string) string {
+	center = xansi.Truncate(center, width, "…")
+	centerWidth := xansi.StringWidth(center)
+
+	if centerWidth == 0 {
+		right = xansi.Truncate(right, width, "…")
+		left = xansi.Truncate(left, max(width-xansi.StringWidth(right)-1, 0), "…")
+		gap := max(width-xansi.StringWidth(left)-xansi.StringWidth(right), 0)
+		return left + strings.Repeat(" ", gap) + right
+	}
+
+	start := (width - centerWidth) / 2
+	end := start + centerWidth
+
+	left = xansi.Truncate(left, max(start-1, 0), "…")
+	right = xansi.Truncate(right, max(width-end-1, 0), "…")
+
+	return left + strings.Repeat(" ", start-xansi.StringWidth(left)) + center +
+		strings.Repeat(" ", max(width-end-xansi.StringWidth(right), 0)) + right
+}
+
+// FormatDuration formats a duration as a hh:mm:ss clock
+func FormatDuration(d time.Duration) string {
+	d = max(d, 0)
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
+
+// FormatRemaining formats the time left in a talk, with a minus sign once
+// it runs over
+func FormatRemaining(d time.Duration) string {
+	if d < 0 {
+		return "-" + timer.Format(d)
+	}
+	return timer.Format(d)
+}
@@ -0,0 +1,54 @@
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLayoutBar(t *testing.T) {
+	tests := []struct {
+		name                string
+		width               int
+		left, center, right string
+		expected            string
+	}{
+		{"All slots", 20, "ab", "mid", "yz", "ab      mid       yz"},
+		{"Sides only", 10, "left", "", "right", "left right"},
+		{"Left only", 6, "ab", "", "", "ab    "},
+		{"Long left shortened before center", 20, "a long left slot", "mid", "", "a long… mid         "},
+		{"Long right shortened after center", 20, "", "mid", "a long right slot", "        mid a long …"},
+		{"Long sides without center", 10, "left side", "", "right", "lef… right"},
+		{"Center wider than the bar", 5, "ab", "a long center", "yz", "a lo…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := layoutBar(tt.width, tt.left, tt.center, tt.right); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration time.Duration
+		expected string
+	}{
+		{"Zero", 0, "00:00:00"},
+		{"Seconds", 42 * time.Second, "00:00:42"},
+		{"Minutes", 12*time.Minute + 5*time.Second, "00:12:05"},
+		{"Hours", 2*time.Hour + 3*time.Minute + 4*time.Second, "02:03:04"},
+		{"Fractions dropped", 1500 * time.Millisecond, "00:00:01"},
+		{"Negative", -time.Minute, "00:00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDuration(tt.duration); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+// * slideColors holds the parsed color metadata of a slide, empty when unset
+type slideColors struct {
+	background lipgloss.Color
+	foreground lipgloss.Color
+	accent     lipgloss.Color
+}
+
+// * colorKey identifies a glamour renderer styled for one set of text colors
+type colorKey struct {
+	foreground lipgloss.Color
+	accent     lipgloss.Color
+	wordWrap   int
+}
+
+// SlideColorError reports the first color metadata of a slide that is not
+// a valid color
+func SlideColorError(metadata models.SlideMetadata) error {
+	_, err := parseSlideColors(metadata)
+	return err
+}
+
+// parseSlideColors parses the color metadata of a slide. Colors that fail
+// to parse are left unset and the first failure is returned.
+func parseSlideColors(metadata models.SlideMetadata) (slideColors, error) {
+	var colors slideColors
+	var firstErr error
+
+	fields := []struct {
+		key   string
+		value string
+		color *lipgloss.Color
+	}{
+		{"background", metadata.Background, &colors.background},
+		{"foreground", metadata.Foreground, &colors.foreground},
+		{"accent", metadata.Accent, &colors.accent},
+	}
+
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		color, err := theme.ParseColor(field.value)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("invalid @%s: %w", field.key, err)
+			}
+			continue
+		}
+		*field.color = color
+	}
+
+	return colors, firstErr
+}
+
+// colors returns the usable colors of a slide. Invalid colors are ignored
+// here and reported by ColorWarning and `slate lint` instead.
+func (r *Renderer) colors(slide *models.Slide) slideColors {
+	colors, _ := parseSlideColors(slide.Metadata)
+	return colors
+}
+
+// colorRenderer returns a glamour renderer whose text and accent colors are
+// replaced by the ones a slide sets
+func (r *Renderer) colorRenderer(colors slideColors, wordWrap int) (*glamour.TermRenderer, error) {
+	key := colorKey{foreground: colors.foreground, accent: colors.accent, wordWrap: wordWrap}
+	if gr, ok := r.colorRenders[key]; ok {
+		return gr, nil
+	}
+
+	// * Pointers are replaced, never written through, so the shared styles stay intact
+	style := r.baseStyle
+	if colors.foreground != "" {
+		style.Document.Color = colorPtr(colors.foreground)
+	}
+	if colors.accent != "" {
+		accent := colorPtr(colors.accent)
+		style.Heading.Color = accent
+		style.Link.Color = accent
+		style.LinkText.Color = accent
+
+		// ? Styles that draw H1 as a colored bar get an accent bar instead
+		if style.H1.BackgroundColor != nil {
+			style.H1.BackgroundColor = accent
+		} else {
+			style.H1.Color = accent
+		}
+	}
+
+	gr, err := glamour.NewTermRenderer(
+		glamour.WithStyles(style),
+		glamour.WithWordWrap(wordWrap),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create glamour renderer: %w", err)
+	}
+
+	r.colorRenders[key] = gr
+	return gr, nil
+}
+
+func colorPtr(c lipgloss.Color) *string {
+	value := string(c)
+	return &value
+}
+
+// defaultColors returns the text and background colors a slide has when it
+// sets none, used to judge the contrast of the colors it does set
+func (r *Renderer) defaultColors() (foreground, background lipgloss.Color) {
+	foreground, background = "252", "0"
+
+	style := r.baseStyle
+	if style.Document.Color != nil {
+		foreground = lipgloss.Color(*style.Document.Color)
+	}
+	if style.Document.BackgroundColor != nil {
+		background = lipgloss.Color(*style.Document.BackgroundColor)
+	} else if theme.IsLightStyle(r.glamourStyle) {
+		background = "15"
+	}
+
+	return foreground, background
+}
+
+// LowContrast reports the lowest contrast between the text or accent color
+// of a slide and its background, and whether it is too low to read. Slides
+// that set no colors are never reported.
+func (r *Renderer) LowContrast(slide *models.Slide) (float64, bool) {
+	colors := r.colors(slide)
+	if colors == (slideColors{}) {
+		return 0, false
+	}
+
+	foreground, background := r.defaultColors()
+	if colors.foreground != "" {
+		foreground = colors.foreground
+	}
+	if colors.background != "" {
+		background = colors.background
+	}
+
+	ratio := theme.ContrastRatio(foreground, background)
+	if colors.accent != "" {
+		ratio = min(ratio, theme.ContrastRatio(colors.accent, background))
+	}
+
+	return ratio, ratio < theme.MinContrast
+}
+
+// ColorWarning describes a problem with the colors of a slide, or returns
+// an empty string when they are fine
+func (r *Renderer) ColorWarning(slide *models.Slide) string {
+	if err := SlideColorError(slide.Metadata); err != nil {
+		return err.Error()
+	}
+
+	if ratio, low := r.LowContrast(slide); low {
+		return fmt.Sprintf("Low contrast: text on this slide is hard to read (%.1f:1, want %.0f:1)",
+			ratio, theme.MinContrast)
+	}
+
+	return ""
+}
+
+// paintBackground fills every line of content to width with the background
+// color, restoring it after each reset the markdown renderer emits
+func paintBackground(content string, width int, background lipgloss.Color) string {
+	color := lipgloss.ColorProfile().Color(string(background))
+	if color == nil {
+		// ? The terminal cannot show colors at all
+		return content
+	}
+	sequence := termenv.CSI + color.Sequence(true) + "m"
+
+	restore := strings.NewReplacer(
+		"\x1b[0m", "\x1b[0m"+sequence,
+		"\x1b[m", "\x1b[m"+sequence,
+		"\x1b[49m", sequence,
+	)
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		padding := strings.Repeat(" ", max(width-xansi.StringWidth(line), 0))
+		lines[i] = sequence + restore.Replace(line) + padding + "\x1b[0m"
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,53 @@
+package display
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+)
+
+func TestLowContrast(t *testing.T) {
+	config := models.NewDefaultConfig()
+	config.Theme.GlamourStyle = "dark"
+
+	r, err := New(config, theme.ColorScheme{}, 80, 24)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		metadata models.SlideMetadata
+		low      bool
+		warning  string
+	}{
+		{"No colors", models.SlideMetadata{}, false, ""},
+		{"Readable background", models.SlideMetadata{Background: "navy"}, false, ""},
+		{"Readable text and background", models.SlideMetadata{Background: "#ffffff", Foreground: "#000000"}, false, ""},
+		{"Light background under light text", models.SlideMetadata{Background: "#eeeeee"}, true, "Low contrast"},
+		{"Dark text on the default background", models.SlideMetadata{Foreground: "#333333"}, true, "Low contrast"},
+		{"Dark accent", models.SlideMetadata{Background: "#000000", Foreground: "#ffffff", Accent: "#111111"}, true, "Low contrast"},
+		{"Invalid color", models.SlideMetadata{Background: "nope"}, false, "invalid @background"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slide := &models.Slide{Metadata: tt.metadata}
+
+			ratio, low := r.LowContrast(slide)
+			if low != tt.low {
+				t.Errorf("Expected low contrast %v, got %v at %.2f:1", tt.low, low, ratio)
+			}
+
+			warning := r.ColorWarning(slide)
+			if tt.warning == "" && warning != "" {
+				t.Errorf("Expected no warning, got %q", warning)
+			}
+			if !strings.Contains(warning, tt.warning) {
+				t.Errorf("Expected the warning to contain %q, got %q", tt.warning, warning)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package display
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+// * Blank columns between two columns of a slide
+const columnGap = 2
+
+// Match the text put in place of a row of columns before rendering
+var columnsTokenRegex = regexp.MustCompile(`slate-columns-(\d+)`)
+
+// replaceColumns puts a token in place of each row of columns, so the row
+// can be drawn where glamour renders the token
+func replaceColumns(content string) (string, []models.ColumnGroup) {
+	groups := data.ParseColumns(content)
+	if len(groups) == 0 {
+		return content, nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	// ? Replace from the bottom so earlier line numbers stay valid
+	for i := len(groups) - 1; i >= 0; i-- {
+		group := groups[i]
+		token := "\n" + "slate-columns-" + strconv.Itoa(i) + "\n"
+		lines = append(lines[:group.Line-1], append([]string{token}, lines[group.EndLine:]...)...)
+	}
+
+	return strings.Join(lines, "\n"), groups
+}
+
+// StackColumns puts the columns of each row one after another, for output
+// that has no room for columns side by side
+func StackColumns(content string) string {
+	content, groups := replaceColumns(content)
+	for i, group := range groups {
+		parts := make([]string, len(group.Columns))
+		for j, column := range group.Columns {
+			parts[j] = column.Content
+		}
+		content = strings.Replace(content, "slate-columns-"+strconv.Itoa(i)+"\n", strings.Join(parts, "\n\n")+"\n", 1)
+	}
+	return content
+}
+
+// drawColumns replaces the lines glamour rendered for column tokens with
+// the columns side by side, each rendered at its own width
+func (r *Renderer) drawColumns(slide *models.Slide, rendered string, groups []models.ColumnGroup) (string, error) {
+	if len(groups) == 0 {
+		return rendered, nil
+	}
+
+	width := r.style.GetWidth() - r.style.GetHorizontalPadding()
+
+	return expandTokens(rendered, columnsTokenRegex, len(groups), func(n, _ int) ([]string, error) {
+		columns := groups[n].Columns
+
+		// ? A fragment may reveal only some of the columns, which keep the
+		// place they have on the full slide
+		if n < len(slide.Columns) {
+			for _, column := range slide.Columns[n].Columns[min(len(columns), len(slide.Columns[n].Columns)):] {
+				columns = append(columns, models.Column{Width: column.Width})
+			}
+		}
+		widths := columnWidths(columns, width)
+
+		parts := make([]string, 0, 2*len(columns))
+		for i, column := range columns {
+			body, err := r.renderColumn(slide, column.Content, widths[i])
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				parts = append(parts, strings.Repeat(" ", columnGap))
+			}
+			parts = append(parts, body)
+		}
+
+		return strings.Split(lipgloss.JoinHorizontal(lipgloss.Top, parts...), "\n"), nil
+	})
+}
+
+// renderColumn renders a column wrapped to its width, with every line
+// exactly that wide so the columns line up
+func (r *Renderer) renderColumn(slide *models.Slide, content string, width int) (string, error) {
+	content, images := r.replaceImages(content)
+
+	gr, err := r.rendererFor(slide, width)
+	if err != nil {
+		return "", err
+	}
+	rendered, err := gr.Render(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+	if rendered, err = r.drawImages(rendered, images, width); err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(rendered, "\n")
+	blank := func(line string) bool { return strings.TrimSpace(xansi.Strip(line)) == "" }
+	for len(lines) > 0 && blank(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && blank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i, line := range lines {
+		line = xansi.Truncate(line, width, "")
+		lines[i] = line + strings.Repeat(" ", max(width-xansi.StringWidth(line), 0))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// columnWidths shares width out among the columns. Columns with a width
+// get their percentage, the others split what is left evenly. Percentages
+// adding up past 100 are scaled down so the columns still fit.
+func columnWidths(columns []models.Column, width int) []int {
+	available := max(width-columnGap*(len(columns)-1), len(columns))
+
+	total := 0
+	for _, column := range columns {
+		total += column.Width
+	}
+	total = max(total, 100)
+
+	widths := make([]int, len(columns))
+	left, shared := available, 0
+	for i, column := range columns {
+		if column.Width > 0 {
+			widths[i] = available * column.Width / total
+			left -= widths[i]
+		} else {
+			shared++
+		}
+	}
+
+	for i, column := range columns {
+		if column.Width == 0 {
+			widths[i] = left / shared
+			// ? The last shared column takes what division leaves over
+			shared--
+			left -= widths[i]
+		}
+		widths[i] = max(widths[i], 1)
+	}
+
+	return widths
+}
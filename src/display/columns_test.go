@@ -0,0 +1,39 @@
+package display
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+func TestColumnWidths(t *testing.T) {
+	tests := []struct {
+		name     string
+		widths   []int
+		width    int
+		expected []int
+	}{
+		{"Even split", []int{0, 0}, 102, []int{50, 50}},
+		{"Remainder shared out", []int{0, 0, 0}, 105, []int{33, 34, 34}},
+		{"Percentages", []int{30, 70}, 102, []int{30, 70}},
+		{"Percentage and shared", []int{40, 0, 0}, 104, []int{40, 30, 30}},
+		{"Percentages past 100 scaled down", []int{80, 70}, 152, []int{80, 70}},
+		{"Percentages past 100 fit", []int{100, 100}, 42, []int{20, 20}},
+		{"Narrow screen", []int{0, 0, 0}, 2, []int{1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := make([]models.Column, len(tt.widths))
+			for i, width := range tt.widths {
+				columns[i].Width = width
+			}
+
+			got := columnWidths(columns, tt.width)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package display
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/ansi/iterm2"
+	"github.com/charmbracelet/x/ansi/kitty"
+	"github.com/muesli/termenv"
+)
+
+// * imageProtocol is how the terminal is able to draw images
+type imageProtocol int
+
+const (
+	// protocolNone leaves images to glamour, which shows them as links
+	protocolNone imageProtocol = iota
+	// protocolBlocks draws images with half block characters
+	protocolBlocks
+	protocolKitty
+	protocolITerm2
+	protocolSixel
+)
+
+// * Size of a terminal cell in pixels assumed when the real one is unknown.
+// Most terminals use cells at least this large, so images stay inside the
+// cells reserved for them.
+const (
+	cellWidth  = 8
+	cellHeight = 16
+)
+
+// detectImageProtocol turns the images setting into a protocol, guessing
+// what the terminal supports from its environment when set to auto
+func detectImageProtocol(setting string) imageProtocol {
+	switch setting {
+	case "off":
+		return protocolNone
+	case "blocks":
+		return protocolBlocks
+	case "kitty":
+		return protocolKitty
+	case "iterm2":
+		return protocolITerm2
+	case "sixel":
+		return protocolSixel
+	}
+
+	// ? Without colors, half blocks would draw a solid shape
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		return protocolNone
+	}
+
+	// ? Multiplexers swallow graphics sequences unless told to pass them on
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return protocolBlocks
+	}
+
+	term, program := os.Getenv("TERM"), os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("KITTY_WINDOW_ID") != "" || term == "xterm-kitty" || term == "xterm-ghostty" || program == "ghostty":
+		return protocolKitty
+	case program == "iTerm.app" || program == "WezTerm" || os.Getenv("LC_TERMINAL") == "iTerm2":
+		return protocolITerm2
+	case strings.HasPrefix(term, "foot") || strings.HasPrefix(term, "mlterm") || strings.Contains(term, "sixel"):
+		return protocolSixel
+	}
+
+	return protocolBlocks
+}
+
+// drawImage returns the lines showing an image over cols x rows cells. The
+// lines are exactly cols wide so they lay out like text.
+func drawImage(protocol imageProtocol, img image.Image, id, cols, rows int) ([]string, error) {
+	switch protocol {
+	case protocolKitty:
+		return kittyImage(img, id, cols, rows)
+	case protocolITerm2:
+		return iterm2Image(img, cols, rows)
+	case protocolSixel:
+		return sixelImage(img, cols, rows), nil
+	}
+	return blockImage(img, cols, rows), nil
+}
+
+// kittyImage sends the image to the terminal once and fills its cells with
+// placeholder characters, which the terminal replaces with the image. The
+// placeholders are plain text, so the image goes away with them.
+func kittyImage(img image.Image, id, cols, rows int) ([]string, error) {
+	var transmit bytes.Buffer
+	err := kitty.EncodeGraphics(&transmit, scaleImage(img, cols*cellWidth, rows*cellHeight), &kitty.Options{
+		Action:           kitty.TransmitAndPut,
+		Format:           kitty.PNG,
+		ID:               id,
+		Columns:          cols,
+		Rows:             rows,
+		VirtualPlacement: true,
+		// ? Replies would arrive as key presses
+		Quite: 2,
+		Chunk: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	// ? The foreground color tells the terminal which image a placeholder
+	// belongs to, and the diacritics which of its cells
+	lines := make([]string, rows)
+	for row := range rows {
+		var line strings.Builder
+		fmt.Fprintf(&line, "\x1b[38;5;%dm", id)
+		for col := range cols {
+			line.WriteRune(kitty.Placeholder)
+			line.WriteRune(kitty.Diacritic(row))
+			line.WriteRune(kitty.Diacritic(col))
+		}
+		line.WriteString("\x1b[39m")
+		lines[row] = line.String()
+	}
+	lines[0] = transmit.String() + lines[0]
+
+	return lines, nil
+}
+
+// iterm2Image draws the image in place and reserves its cells with spaces
+func iterm2Image(img image.Image, cols, rows int) ([]string, error) {
+	var data bytes.Buffer
+	if err := png.Encode(&data, scaleImage(img, cols*cellWidth, rows*cellHeight)); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+
+	sequence := xansi.ITerm2(iterm2.File{
+		Name:            base64.StdEncoding.EncodeToString([]byte("slide.png")),
+		Size:            int64(data.Len()),
+		Width:           iterm2.Cells(cols),
+		Height:          iterm2.Cells(rows),
+		Inline:          true,
+		DoNotMoveCursor: true,
+		Content:         []byte(base64.StdEncoding.EncodeToString(data.Bytes())),
+	})
+	return reservedLines(sequence, cols, rows), nil
+}
+
+// sixelImage draws the image as sixels, which paint pixels over the cells
+func sixelImage(img image.Image, cols, rows int) []string {
+	payload := encodeSixel(scaleImage(img, cols*cellWidth, rows*cellHeight))
+	return reservedLines(xansi.SixelGraphics(0, 1, 0, payload), cols, rows)
+}
+
+// reservedLines draws an image sequence from the top left cell, keeping the
+// cursor where it was, and fills the cells the image covers with spaces.
+// Each line ends with a reset that text lines lack, so redrawing the screen
+// over an old image repaints its cells.
+func reservedLines(sequence string, cols, rows int) []string {
+	blank := strings.Repeat(" ", cols) + "\x1b[0m"
+
+	lines := make([]string, rows)
+	for i := range lines {
+		lines[i] = blank
+	}
+	lines[0] = xansi.SaveCursor + sequence + xansi.RestoreCursor + blank
+
+	return lines
+}
+
+// blockImage draws the image with half blocks, each cell showing two pixels
+// stacked on top of each other
+func blockImage(img image.Image, cols, rows int) []string {
+	scaled := scaleImage(img, cols, rows*2)
+	profile := lipgloss.ColorProfile()
+
+	lines := make([]string, rows)
+	for row := range rows {
+		var line strings.Builder
+		for col := range cols {
+			top := profile.FromColor(scaled.At(col, row*2))
+			bottom := profile.FromColor(scaled.At(col, row*2+1))
+			line.WriteString(termenv.CSI + top.Sequence(false) + ";" + bottom.Sequence(true) + "m▀")
+		}
+		line.WriteString(termenv.CSI + termenv.ResetSeq + "m")
+		lines[row] = line.String()
+	}
+
+	return lines
+}
+
+// scaleImage resizes an image to width x height, averaging the pixels that
+// fall into each new one. Transparent pixels are blended onto black.
+func scaleImage(img image.Image, width, height int) *image.RGBA {
+	bounds := img.Bounds()
+	scaled := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := range height {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+		for x := range width {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+
+			// ? Colors are premultiplied, so leaving out alpha blends onto black
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, _ := img.At(sx, sy).RGBA()
+					r, g, b = r+uint64(pr), g+uint64(pg), b+uint64(pb)
+					n++
+				}
+			}
+
+			scaled.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+
+	return scaled
+}
+
+// encodeSixel writes an image as sixel data, with colors reduced to a
+// 6x6x6 color cube
+func encodeSixel(img *image.RGBA) []byte {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// * Map each pixel to its palette entry
+	levels := func(v uint8) int { return (int(v) + 25) / 51 }
+	index := make([]int, width*height)
+	used := make(map[int]bool)
+	for y := range height {
+		for x := range width {
+			c := img.RGBAAt(x, y)
+			i := levels(c.R)*36 + levels(c.G)*6 + levels(c.B)
+			index[y*width+x] = i
+			used[i] = true
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "\"1;1;%d;%d", width, height)
+	for i := range 216 {
+		if used[i] {
+			// ? Sixel colors are given in percent
+			fmt.Fprintf(&out, "#%d;2;%d;%d;%d", i, i/36*20, i/6%6*20, i%6*20)
+		}
+	}
+
+	// * Draw bands six pixels high, one color at a time
+	bits := make([]byte, width)
+	for top := 0; top < height; top += 6 {
+		colors := make(map[int]bool)
+		for y := top; y < min(top+6, height); y++ {
+			for x := range width {
+				colors[index[y*width+x]] = true
+			}
+		}
+
+		first := true
+		for i := range 216 {
+			if !colors[i] {
+				continue
+			}
+			if !first {
+				out.WriteByte('$')
+			}
+			first = false
+
+			for x := range width {
+				bits[x] = 0
+				for dy := range min(6, height-top) {
+					if index[(top+dy)*width+x] == i {
+						bits[x] |= 1 << dy
+					}
+				}
+			}
+			fmt.Fprintf(&out, "#%d", i)
+			writeSixelRun(&out, bits)
+		}
+		out.WriteByte('-')
+	}
+
+	return out.Bytes()
+}
+
+// writeSixelRun writes a row of sixels, shortening repeats
+func writeSixelRun(out *bytes.Buffer, bits []byte) {
+	for x := 0; x < len(bits); {
+		run := 1
+		for x+run < len(bits) && bits[x+run] == bits[x] {
+			run++
+		}
+
+		char := byte('?') + bits[x]
+		if run > 3 {
+			fmt.Fprintf(out, "!%d%c", run, char)
+		} else {
+			out.Write(bytes.Repeat([]byte{char}, run))
+		}
+		x += run
+	}
+}
@@ -0,0 +1,134 @@
+package display
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	highlightOn  = "\x1b[7m"
+	highlightOff = "\x1b[27m"
+)
+
+// Highlight shows every match of re in reverse video. Matching runs on the
+// visible text of each line, so styling inside a match is kept and the
+// highlight is restored after any reset glamour emits.
+func Highlight(rendered string, re *regexp.Regexp) string {
+	lines := strings.Split(rendered, "\n")
+	for i, line := range lines {
+		lines[i] = highlightLine(line, re)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func highlightLine(line string, re *regexp.Regexp) string {
+	// * Collect the visible text and where each of its bytes sits in the line
+	var plain strings.Builder
+	offsets := make([]int, 0, len(line))
+
+	for i := 0; i < len(line); {
+		if end := escapeEnd(line, i); end > i {
+			i = end
+			continue
+		}
+		plain.WriteByte(line[i])
+		offsets = append(offsets, i)
+		i++
+	}
+
+	matches := re.FindAllStringIndex(plain.String(), -1)
+	if len(matches) == 0 {
+		return line
+	}
+
+	// * Mark which visible bytes are inside a match
+	inside := make([]bool, len(offsets))
+	for _, match := range matches {
+		for j := match[0]; j < match[1]; j++ {
+			inside[j] = true
+		}
+	}
+
+	var out strings.Builder
+	active := false
+	visible := 0
+
+	for i := 0; i < len(line); {
+		if end := escapeEnd(line, i); end > i {
+			out.WriteString(line[i:end])
+			// ? Styles inside the match may reset reverse video
+			if active {
+				out.WriteString(highlightOn)
+			}
+			i = end
+			continue
+		}
+
+		if inside[visible] != active {
+			active = inside[visible]
+			if active {
+				out.WriteString(highlightOn)
+			} else {
+				out.WriteString(highlightOff)
+			}
+		}
+
+		out.WriteByte(line[i])
+		visible++
+		i++
+	}
+
+	if active {
+		out.WriteString(highlightOff)
+	}
+
+	return out.String()
+}
+
+// visibleText drops the escape sequences from a line, as matched by Highlight
+func visibleText(line string) string {
+	var plain strings.Builder
+	for i := 0; i < len(line); {
+		if end := escapeEnd(line, i); end > i {
+			i = end
+			continue
+		}
+		plain.WriteByte(line[i])
+		i++
+	}
+	return plain.String()
+}
+
+// escapeEnd returns the end of the escape sequence starting at i, or i when
+// there is none. Besides CSI sequences this skips the string sequences
+// images are drawn with.
+func escapeEnd(line string, i int) int {
+	if line[i] != '\x1b' || i+1 >= len(line) {
+		return i
+	}
+
+	switch line[i+1] {
+	case '[':
+		for j := i + 2; j < len(line); j++ {
+			if line[j] >= 0x40 && line[j] <= 0x7e {
+				return j + 1
+			}
+		}
+		return len(line)
+	case '_', ']', 'P':
+		// ? String sequences end with ST, or BEL for OSC
+		for j := i + 2; j < len(line); j++ {
+			if line[j] == '\a' {
+				return j + 1
+			}
+			if line[j] == '\x1b' && j+1 < len(line) && line[j+1] == '\\' {
+				return j + 2
+			}
+		}
+		return len(line)
+	case '7', '8':
+		return i + 2
+	}
+	return i
+}
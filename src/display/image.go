@@ -0,0 +1,224 @@
+package display
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"  // * Register GIF decoding
+	_ "image/jpeg" // * Register JPEG decoding
+	_ "image/png"  // * Register PNG decoding
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/data"
+)
+
+var (
+	// Match an image standing alone on its line and capture its path
+	imageLineRegex = regexp.MustCompile(`^[ \t]*!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)[ \t]*$`)
+	// Match the text put in place of an image before rendering
+	imageTokenRegex = regexp.MustCompile(`slate-image-(\d+)`)
+)
+
+// * imageFile is a local image file as it was when last read
+type imageFile struct {
+	path    string
+	modTime time.Time
+}
+
+// * imageKey names an image drawn at a size
+type imageKey struct {
+	file imageFile
+	cols int
+	rows int
+}
+
+// SetBaseDir sets the directory image paths in slides are relative to,
+// usually the one holding the presentation file
+func (r *Renderer) SetBaseDir(dir string) {
+	r.baseDir = dir
+}
+
+// replaceImages puts a token in place of each local image standing on its
+// own line, so the image can be drawn where glamour renders the token.
+// Images inside code, remote images and files that cannot be decoded are
+// left for glamour to show as links.
+func (r *Renderer) replaceImages(content string) (string, []imageFile) {
+	if r.imageProtocol == protocolNone || !strings.Contains(content, "![") {
+		return content, nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	var files []imageFile
+
+	for _, token := range data.Tokenize(content) {
+		if token.Kind != data.TokenText {
+			continue
+		}
+		for i, line := range token.Lines {
+			match := imageLineRegex.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			file, ok := r.imageFile(match[1])
+			if !ok {
+				continue
+			}
+			if _, err := r.loadImage(file); err != nil {
+				continue
+			}
+
+			// ? Blank lines keep the token out of any paragraph around it
+			lines[token.Line-1+i] = "\n" + "slate-image-" + strconv.Itoa(len(files)) + "\n"
+			files = append(files, file)
+		}
+	}
+
+	return strings.Join(lines, "\n"), files
+}
+
+// ImagePaths lists the local images standing on their own line in content,
+// the ones drawn on the slide, with relative paths joined to dir
+func ImagePaths(content, dir string) []string {
+	if !strings.Contains(content, "![") {
+		return nil
+	}
+
+	var paths []string
+	for _, token := range data.Tokenize(content) {
+		if token.Kind != data.TokenText {
+			continue
+		}
+		for _, line := range token.Lines {
+			match := imageLineRegex.FindStringSubmatch(line)
+			if match == nil || strings.Contains(match[1], "://") {
+				continue
+			}
+			path := filepath.FromSlash(match[1])
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// imageFile finds a local image, relative to the presentation
+func (r *Renderer) imageFile(src string) (imageFile, bool) {
+	if strings.Contains(src, "://") {
+		return imageFile{}, false
+	}
+
+	path := filepath.FromSlash(src)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.baseDir, path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return imageFile{}, false
+	}
+
+	return imageFile{path: path, modTime: info.ModTime()}, true
+}
+
+// imageWidth is the widest an image on the slide may be, the same as text
+func (r *Renderer) imageWidth() int {
+	width := r.style.GetWidth() - r.style.GetHorizontalPadding()
+	if wordWrap := r.config.Presentation.WordWrap; wordWrap > 0 {
+		width = min(width, wordWrap)
+	}
+	return width
+}
+
+// drawImages replaces the lines glamour rendered for image tokens with the
+// images, keeping the indent of the line
+func (r *Renderer) drawImages(rendered string, files []imageFile, width int) (string, error) {
+	if len(files) == 0 {
+		return rendered, nil
+	}
+
+	height := max(r.viewportHeight()-2, 1)
+
+	return expandTokens(rendered, imageTokenRegex, len(files), func(n, indent int) ([]string, error) {
+		drawn, err := r.drawImage(files[n], max(width-indent, 1), height)
+		if err != nil {
+			return nil, err
+		}
+
+		lines := make([]string, len(drawn))
+		for i, row := range drawn {
+			lines[i] = strings.Repeat(" ", indent) + row
+		}
+		return lines, nil
+	})
+}
+
+// drawImage draws an image scaled to fit maxCols x maxRows cells, reusing
+// the drawing for that size when there is one
+func (r *Renderer) drawImage(file imageFile, maxCols, maxRows int) ([]string, error) {
+	img, err := r.loadImage(file)
+	if err != nil {
+		return nil, err
+	}
+
+	cols, rows := fitImage(img.Bounds().Dx(), img.Bounds().Dy(), maxCols, maxRows)
+	key := imageKey{file: file, cols: cols, rows: rows}
+	if lines, ok := r.drawnImages[key]; ok {
+		return lines, nil
+	}
+
+	// ? Kitty tells images apart by the color of their cells, one of 255
+	r.lastImageID = r.lastImageID%255 + 1
+
+	lines, err := drawImage(r.imageProtocol, img, r.lastImageID, cols, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	r.drawnImages[key] = lines
+	return lines, nil
+}
+
+func (r *Renderer) loadImage(file imageFile) (image.Image, error) {
+	if img, ok := r.images[file]; ok {
+		return img, nil
+	}
+
+	f, err := os.Open(file.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image %s: %w", filepath.Base(file.path), err)
+	}
+
+	r.images[file] = img
+	return img, nil
+}
+
+// fitImage returns how many cells an image of width x height pixels covers
+// when scaled to fit maxCols x maxRows, keeping its aspect ratio. Images are
+// never scaled past their size in pixels.
+func fitImage(width, height, maxCols, maxRows int) (int, int) {
+	if width <= 0 || height <= 0 {
+		return 1, 1
+	}
+
+	cols := min(maxCols, (width+cellWidth-1)/cellWidth)
+	rows := cols * height * cellWidth / (width * cellHeight)
+	if rows > maxRows {
+		rows = maxRows
+		cols = rows * width * cellHeight / (height * cellWidth)
+	}
+
+	return max(cols, 1), max(rows, 1)
+}
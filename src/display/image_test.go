@@ -0,0 +1,42 @@
+package display
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFitImage(t *testing.T) {
+	tests := []struct {
+		name             string
+		width, height    int
+		maxCols, maxRows int
+		cols, rows       int
+	}{
+		{"Fits as is", 80, 32, 40, 20, 10, 2},
+		{"Scaled to the width", 800, 160, 40, 20, 40, 4},
+		{"Scaled to the height", 160, 800, 40, 20, 8, 20},
+		{"Partial cells round up", 81, 32, 40, 20, 11, 2},
+		{"Tiny image", 1, 1, 40, 20, 1, 1},
+		{"Empty image", 0, 0, 40, 20, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols, rows := fitImage(tt.width, tt.height, tt.maxCols, tt.maxRows)
+			if cols != tt.cols || rows != tt.rows {
+				t.Errorf("Expected %dx%d cells, got %dx%d", tt.cols, tt.rows, cols, rows)
+			}
+		})
+	}
+}
+
+func TestImagePaths(t *testing.T) {
+	content := "# Pictures\n\n![Local](images/a.png)\n\nText with ![inline](b.png) image\n\n" +
+		"![Remote](https://example.com/c.png)\n\n```md\n![Code](d.png)\n```\n\n![Absolute](/tmp/e.png)\n"
+
+	expected := []string{filepath.Join("deck", "images", "a.png"), filepath.FromSlash("/tmp/e.png")}
+	if got := ImagePaths(content, "deck"); !slices.Equal(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,69 @@
+package display
+
+import (
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	xansi "github.com/charmbracelet/x/ansi"
+)
+
+// * CodeOutput is what a code block printed when run from the slide
+type CodeOutput struct {
+	Text string
+	// Status describes the run, such as running or the exit status
+	Status string
+}
+
+// renderBody renders the slide with the output of its blocks that have run
+// placed below them. Slides with output skip the cache, as it keeps growing.
+func (r *Renderer) renderBody(slide *models.Slide, fragment int, outputs map[int]CodeOutput) (string, error) {
+	if len(outputs) == 0 {
+		return r.RenderFragment(slide, fragment)
+	}
+	return r.renderMarkdown(slide, withOutputs(slide, slide.FragmentContent(fragment), outputs))
+}
+
+// withOutputs adds an output pane after each block that has output, leaving
+// out blocks not revealed yet. Blocks are looked up in order, each after the
+// one before, so identical blocks get their own output.
+func withOutputs(slide *models.Slide, content string, outputs map[int]CodeOutput) string {
+	from := 0
+	for i, block := range slide.CodeBlocks {
+		at := strings.Index(content[from:], block.Source)
+		if at < 0 {
+			continue
+		}
+		at += from + len(block.Source)
+		from = at
+
+		output, ok := outputs[i]
+		if !ok {
+			continue
+		}
+
+		pane := "\n\n" + outputPane(output)
+		content = content[:at] + pane + content[at:]
+		from += len(pane)
+	}
+	return content
+}
+
+// outputPane writes output as a plain code block followed by the status
+func outputPane(output CodeOutput) string {
+	// ? Output is shown as text, so escapes and carriage returns must go
+	text := xansi.Strip(strings.ReplaceAll(output.Text, "\r", ""))
+	text = strings.TrimRight(text, "\n")
+
+	// ? The fence must be longer than any backtick run in the output
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+
+	var pane strings.Builder
+	if text != "" {
+		pane.WriteString(fence + "text\n" + text + "\n" + fence + "\n\n")
+	}
+	pane.WriteString("*▶ " + output.Status + "*")
+	return pane.String()
+}
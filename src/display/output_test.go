@@ -0,0 +1,56 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+func TestWithOutputs(t *testing.T) {
+	block := "```sh run\necho hi\n```"
+	slide := &models.Slide{CodeBlocks: []models.CodeBlock{
+		{Language: "sh", Code: "echo hi\n", Source: block},
+		{Language: "sh", Code: "echo hi\n", Source: block},
+	}}
+	content := "# Twice\n\n" + block + "\n\nAgain\n\n" + block
+
+	tests := []struct {
+		name     string
+		content  string
+		outputs  map[int]CodeOutput
+		expected string
+	}{
+		{
+			"First of identical blocks",
+			content,
+			map[int]CodeOutput{0: {Text: "one\n", Status: "exit 0"}},
+			"# Twice\n\n" + block + "\n\n```text\none\n```\n\n*▶ exit 0*\n\nAgain\n\n" + block,
+		},
+		{
+			"Second of identical blocks",
+			content,
+			map[int]CodeOutput{1: {Status: "running"}},
+			"# Twice\n\n" + block + "\n\nAgain\n\n" + block + "\n\n*▶ running*",
+		},
+		{
+			"Both blocks",
+			content,
+			map[int]CodeOutput{0: {Status: "first"}, 1: {Status: "second"}},
+			"# Twice\n\n" + block + "\n\n*▶ first*\n\nAgain\n\n" + block + "\n\n*▶ second*",
+		},
+		{
+			"Block not revealed yet",
+			"# Twice\n\n" + block,
+			map[int]CodeOutput{1: {Status: "second"}},
+			"# Twice\n\n" + block,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := withOutputs(slide, tt.content, tt.outputs); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package display
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Overlay draws box centered over a rendered screen of width x height
+// cells, keeping the screen visible around it. Boxes larger than the
+// screen are cut at its edges.
+func Overlay(screen, box string, width, height int) string {
+	lines := screenLines(screen, width, height)
+	boxLines := strings.Split(box, "\n")
+
+	boxWidth := 0
+	for _, line := range boxLines {
+		boxWidth = max(boxWidth, ansi.StringWidth(line))
+	}
+	boxWidth = min(boxWidth, width)
+
+	left := (width - boxWidth) / 2
+	top := max((height-len(boxLines))/2, 0)
+
+	for i, line := range boxLines {
+		row := top + i
+		if row >= height {
+			break
+		}
+		line = ansi.Truncate(line, boxWidth, "")
+		line += strings.Repeat(" ", boxWidth-ansi.StringWidth(line))
+		lines[row] = cut(lines[row], 0, left) + line + resetAll + cut(lines[row], left+boxWidth, width)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,32 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestOverlay(t *testing.T) {
+	screen := "abcdefgh\nijklmnop\nqrstuvwx\nyz"
+
+	tests := []struct {
+		name     string
+		box      string
+		width    int
+		height   int
+		expected string
+	}{
+		{"Centered", "##\n##", 8, 4, "abcdefgh\nijk##nop\nqrs##vwx\nyz      "},
+		{"Uneven lines padded", "###\n#", 8, 4, "abcdefgh\nij###nop\nqr#  vwx\nyz      "},
+		{"Wider than the screen", "##########", 8, 2, "########\nijklmnop"},
+		{"Taller than the screen", "1\n2\n3\n4", 8, 2, "abc1efgh\nijk2mnop"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ansi.Strip(Overlay(screen, tt.box, tt.width, tt.height)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
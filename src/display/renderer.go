@@ -2,81 +2,180 @@ package display
 
 import (
 	"fmt"
+	"image"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/Kosha-Nirman/slate/src/timer"
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/ansi"
 	"github.com/charmbracelet/lipgloss"
+	xansi "github.com/charmbracelet/x/ansi"
 )
 
+// Match metadata comments and directives, which may span several lines.
+// Comments on lines of their own are removed along with the line.
+var metadataCommentRegex = regexp.MustCompile(`(?ms)^[ \t]*<!--\s*@.*?-->[ \t]*(?:\n|\z)|<!--\s*@.*?-->`)
+
 type Renderer struct {
 	glamourRender *glamour.TermRenderer
+	glamourStyle  string
+	baseStyle     ansi.StyleConfig
 	width         int
 	height        int
 	config        *models.Config
 	style         lipgloss.Style
+
+	// * Colors of the progress bar, header, footer and errors
+	scheme theme.ColorScheme
+
+	// * Renderers for previews and columns, keyed by word wrap width
+	previewRenders map[int]*glamour.TermRenderer
+
+	// * Renderers for slides that set their own text colors
+	colorRenders map[colorKey]*glamour.TermRenderer
+
+	// * Rendered thumbnails, dropped whenever the slide caches are cleared
+	thumbnails map[thumbnailKey]string
+
+	// * Images are found relative to baseDir and drawn with imageProtocol.
+	// Decoded images and their drawings at each size are kept, so slides
+	// render quickly again after a resize.
+	baseDir       string
+	imageProtocol imageProtocol
+	images        map[imageFile]image.Image
+	drawnImages   map[imageKey][]string
+	lastImageID   int
+
+	// * Search pattern highlighted in rendered slides
+	highlight *regexp.Regexp
+
+	// * Header and footer bars, and whether they show a running clock
+	header *bar
+	footer *bar
+	ticks  bool
+}
+
+// * Frame describes one screen of the presentation view
+type Frame struct {
+	Slide    *models.Slide
+	Fragment int
+	Current  int
+	Total    int
+	// Scroll is the first visible line of the slide body
+	Scroll int
+	// Outputs holds what the slide's code blocks printed, by block index
+	Outputs map[int]CodeOutput
+	// Terminal is the screen of the slide's terminal, empty until started
+	Terminal        string
+	TerminalFocused bool
+	// Deck, Elapsed and Plan fill in the header and footer templates
+	Deck    *models.Presentation
+	Elapsed time.Duration
+	Plan    timer.Plan
 }
 
-func New(config *models.Config, width, height int) (*Renderer, error) {
+type thumbnailKey struct {
+	slide  *models.Slide
+	width  int
+	height int
+}
+
+func newGlamourRenderer(style ansi.StyleConfig, wordWrap int) (*glamour.TermRenderer, error) {
+	gr, err := glamour.NewTermRenderer(
+		glamour.WithStyles(style),
+		glamour.WithWordWrap(wordWrap),
+		// * Downsample colors to what the terminal supports
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create glamour renderer: %w", err)
+	}
+
+	return gr, nil
+}
+
+// New creates a renderer for the config, drawing the chrome in scheme. The
+// scheme comes from the caller's theme manager, which has already settled
+// on dark or light.
+func New(config *models.Config, scheme theme.ColorScheme, width, height int) (*Renderer, error) {
 	glamourStyle := config.Theme.GlamourStyle
 	if glamourStyle == "" {
 		glamourStyle = "dark"
 	}
 
+	// * Keep the style itself so slides can override its colors
+	baseStyle, err := theme.LoadStyle(glamourStyle)
+	if err != nil {
+		return nil, err
+	}
+
 	// * Create glamour renderer
-	gr, err := glamour.NewTermRenderer(
-		glamour.WithStylePath(glamourStyle),
-		glamour.WithWordWrap(config.Presentation.WordWrap),
-	)
+	gr, err := newGlamourRenderer(baseStyle, config.Presentation.WordWrap)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create glamour renderer: %w", err)
+		return nil, err
+	}
+
+	r := &Renderer{
+		glamourRender:  gr,
+		glamourStyle:   glamourStyle,
+		baseStyle:      baseStyle,
+		config:         config,
+		previewRenders: make(map[int]*glamour.TermRenderer),
+		colorRenders:   make(map[colorKey]*glamour.TermRenderer),
+		thumbnails:     make(map[thumbnailKey]string),
+		imageProtocol:  detectImageProtocol(config.Presentation.Images),
+		images:         make(map[imageFile]image.Image),
+		drawnImages:    make(map[imageKey][]string),
+		scheme:         scheme,
 	}
 
 	// * Create base style
-	style := lipgloss.NewStyle().
-		Width(width - (config.Presentation.Margin * 2)).
-		Height(height - (config.Presentation.Margin * 2)).
+	r.style = lipgloss.NewStyle().
 		Padding(config.Presentation.Padding).
 		Margin(config.Presentation.Margin)
+	r.width, r.height = width, height
 
-	return &Renderer{
-		glamourRender: gr,
-		width:         width,
-		height:        height,
-		config:        config,
-		style:         style,
-	}, nil
+	// * Sizes the slide area around the header and footer
+	if err := r.SetLayout(config.Layout); err != nil {
+		return nil, err
+	}
 
+	return r, nil
 }
 
-func (r *Renderer) stripMetadataComments(content string) string {
-	// * Remove <!-- @key: value --> style comments
-	lines := strings.Split(content, "\n")
-	filtered := make([]string, 0, len(lines))
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "<!--") && strings.Contains(trimmed, "@") {
-			continue
-		}
-		filtered = append(filtered, line)
+// chromeLines counts the lines drawn above and below the slide
+func (r *Renderer) chromeLines() int {
+	lines := 0
+	if r.config.Theme.ShowProgress {
+		lines++
 	}
-
-	return strings.Join(filtered, "\n")
+	if r.header != nil {
+		lines++
+	}
+	if r.footer != nil {
+		lines++
+	}
+	return lines
 }
 
-func (r *Renderer) renderSlideNumber(current, total int) string {
-	text := fmt.Sprintf("%d / %d", current+1, total)
-
-	style := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
-		Width(r.width).
-		Align(lipgloss.Right)
+// viewportHeight is the number of slide body lines that fit on screen
+func (r *Renderer) viewportHeight() int {
+	presentation := r.config.Presentation
+	return max(r.height-2*presentation.Margin-2*presentation.Padding-r.chromeLines(), 1)
+}
 
-	return style.Render(text)
+// StripMetadataComments removes <!-- @key: value --> style comments, including
+// ones spanning several lines, so they never reach the rendered output
+func StripMetadataComments(content string) string {
+	return metadataCommentRegex.ReplaceAllString(content, "")
 }
 
-func (r *Renderer) renderProgressBar(current, total int) string {
+func (r *Renderer) renderProgressBar(current, total int, background lipgloss.Color) string {
 	if total == 0 {
 		return ""
 	}
@@ -89,84 +188,400 @@ func (r *Renderer) renderProgressBar(current, total int) string {
 	bar := strings.Repeat("━", filled) + strings.Repeat("─", empty)
 
 	style := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("63")).
+		Foreground(r.scheme.ProgressBar).
 		Width(r.width).
 		Align(lipgloss.Center)
+	if background != "" {
+		style = style.Background(background)
+	}
 
 	return style.Render(bar)
 }
 
+func (r *Renderer) renderMarkdown(slide *models.Slide, content string) (string, error) {
+	// * Remove slide metadata comments
+	content = StripMetadataComments(content)
+
+	// * Set columns, big headings and images aside to draw them once glamour has laid out the text
+	content, columns := replaceColumns(content)
+	content, headings := r.replaceBigText(slide, content)
+	content, images := r.replaceImages(content)
+
+	gr, err := r.rendererFor(slide, r.config.Presentation.WordWrap)
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := gr.Render(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	if rendered, err = r.drawImages(rendered, images, r.imageWidth()); err != nil {
+		return "", err
+	}
+	if rendered, err = r.drawBigText(slide, rendered, headings); err != nil {
+		return "", err
+	}
+	if rendered, err = r.drawColumns(slide, rendered, columns); err != nil {
+		return "", err
+	}
+
+	// ? Cover and closing slides are centered on screen
+	if slide.IsGenerated() {
+		return r.RenderTitle(rendered), nil
+	}
+
+	return strings.TrimRight(rendered, "\n"), nil
+}
+
+// rendererFor returns a glamour renderer wrapping at wordWrap, in the
+// slide's own colors when it sets them
+func (r *Renderer) rendererFor(slide *models.Slide, wordWrap int) (*glamour.TermRenderer, error) {
+	if colors := r.colors(slide); colors.foreground != "" || colors.accent != "" {
+		return r.colorRenderer(colors, wordWrap)
+	}
+	if wordWrap == r.config.Presentation.WordWrap {
+		return r.glamourRender, nil
+	}
+
+	if gr, ok := r.previewRenders[wordWrap]; ok {
+		return gr, nil
+	}
+	gr, err := newGlamourRenderer(r.baseStyle, wordWrap)
+	if err != nil {
+		return nil, err
+	}
+	r.previewRenders[wordWrap] = gr
+
+	return gr, nil
+}
+
+// expandTokens replaces each rendered line holding a token matched by re
+// with the lines expand returns for the token's number. expand gets the
+// indent of the line too.
+func expandTokens(rendered string, re *regexp.Regexp, count int, expand func(n, indent int) ([]string, error)) (string, error) {
+	lines := strings.Split(rendered, "\n")
+	result := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		plain := xansi.Strip(line)
+		match := re.FindStringSubmatch(plain)
+		n := -1
+		if match != nil {
+			n, _ = strconv.Atoi(match[1])
+		}
+		if n < 0 || n >= count {
+			result = append(result, line)
+			continue
+		}
+
+		expanded, err := expand(n, len(plain)-len(strings.TrimLeft(plain, " ")))
+		if err != nil {
+			return "", err
+		}
+		result = append(result, expanded...)
+	}
+
+	return strings.Join(result, "\n"), nil
+}
+
 func (r *Renderer) RenderSlide(slide *models.Slide) (string, error) {
 	if slide.HasCache() {
 		return slide.GetRenderedCache(), nil
 	}
 
-	content := slide.Content()
+	rendered, err := r.renderMarkdown(slide, slide.Content())
+	if err != nil {
+		return "", err
+	}
 
-	// * Remove slide metadata comments
-	content = r.stripMetadataComments(content)
+	// * Cache rendered output
+	slide.SetRenderedCache(rendered)
+
+	return rendered, nil
+}
+
+// RenderFragment renders the slide as it looks with fragments up to and
+// including the given one revealed
+func (r *Renderer) RenderFragment(slide *models.Slide, fragment int) (string, error) {
+	if slide.IsLastFragment(fragment) {
+		return r.RenderSlide(slide)
+	}
 
-	rendered, err := r.glamourRender.Render(content)
+	if slide.HasFragmentCache(fragment) {
+		return slide.GetFragmentCache(fragment), nil
+	}
+
+	rendered, err := r.renderMarkdown(slide, slide.FragmentContent(fragment))
 	if err != nil {
-		return "", fmt.Errorf("failed to render markdown: %w", err)
+		return "", err
 	}
 
-	// * Apply styling
-	styled := r.style.Render(rendered)
+	// * Cache rendered output per fragment state
+	slide.SetFragmentCache(fragment, rendered)
 
-	// * Cache rendered output
-	slide.SetRenderedCache(styled)
+	return rendered, nil
+}
+
+// MaxScroll returns how far the slide body can scroll before its last line
+// reaches the bottom of the screen
+func (r *Renderer) MaxScroll(slide *models.Slide, fragment int, outputs map[int]CodeOutput) (int, error) {
+	body, err := r.renderBody(slide, fragment, outputs)
+	if err != nil {
+		return 0, err
+	}
+
+	return maxScroll(strings.Count(body, "\n")+1, r.viewportHeight()), nil
+}
+
+func maxScroll(lines, height int) int {
+	if lines <= height {
+		return 0
+	}
+	// ? Once scrolled, the top line is taken by the overflow indicator
+	return lines - height + 1
+}
+
+// viewport cuts the visible part out of the slide body and marks how many
+// lines are hidden above and below
+func (r *Renderer) viewport(body string, scroll int) string {
+	lines := strings.Split(body, "\n")
+	height := r.viewportHeight()
+
+	scroll = min(max(scroll, 0), maxScroll(len(lines), height))
+	if len(lines) <= height {
+		return body
+	}
+
+	available := height
+	if scroll > 0 {
+		available--
+	}
+	if scroll+available < len(lines) {
+		available--
+	}
+	visible := lines[scroll : scroll+max(available, 0)]
+
+	indicator := lipgloss.NewStyle().
+		Foreground(r.scheme.Muted).
+		Faint(true)
+
+	result := make([]string, 0, height)
+	if scroll > 0 {
+		result = append(result, indicator.Render(fmt.Sprintf("  ▲ %d more lines", scroll)))
+	}
+	result = append(result, visible...)
+	if hidden := len(lines) - scroll - len(visible); hidden > 0 {
+		result = append(result, indicator.Render(fmt.Sprintf("  ▼ %d more lines", hidden)))
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// frameBody renders the whole body of a frame, before it is cut to the
+// lines that fit on screen
+func (r *Renderer) frameBody(frame Frame) (string, error) {
+	body, err := r.renderBody(frame.Slide, frame.Fragment, frame.Outputs)
+	if err != nil {
+		return "", err
+	}
 
-	return styled, nil
+	// * Terminal slides host a terminal below their content
+	if frame.Slide.Metadata.Terminal {
+		body += "\n\n" + r.terminalPane(frame)
+	}
+
+	// ? Cover and closing slides lay themselves out
+	if !frame.Slide.IsGenerated() {
+		align, justify := r.placement(frame.Slide, frame.Deck)
+		body = r.placeBody(body, align, justify)
+	}
+
+	return body, nil
 }
 
-func (r *Renderer) RenderWithProgress(slide *models.Slide, current, total int) (string, error) {
-	slideContent, err := r.RenderSlide(slide)
+// MatchScroll returns the scroll position that brings the first line
+// matching re into view. Matches already on screen keep the slide at the top.
+func (r *Renderer) MatchScroll(frame Frame, re *regexp.Regexp) (int, error) {
+	body, err := r.frameBody(frame)
+	if err != nil {
+		return 0, err
+	}
+
+	lines := strings.Split(body, "\n")
+	height := r.viewportHeight()
+	for i, line := range lines {
+		if !re.MatchString(visibleText(line)) {
+			continue
+		}
+		// ? Unscrolled, the bottom line marks the lines hidden below
+		if len(lines) <= height || i < height-1 {
+			return 0, nil
+		}
+		return min(i, maxScroll(len(lines), height)), nil
+	}
+
+	return 0, nil
+}
+
+// RenderFrame renders the visible part of a slide with the header above it
+// and the progress bar and footer below it
+func (r *Renderer) RenderFrame(frame Frame) (string, error) {
+	body, err := r.frameBody(frame)
 	if err != nil {
 		return "", err
 	}
 
+	// ? Highlight search matches on top of the cached render
+	if r.highlight != nil {
+		body = Highlight(body, r.highlight)
+	}
+
+	body = r.viewport(body, frame.Scroll)
+	style := r.style
+
+	// ? A slide background fills the whole window, margins included
+	background := r.colors(frame.Slide).background
+	if background != "" {
+		body = paintBackground(body, style.GetWidth()-style.GetHorizontalPadding(), background)
+		style = style.Background(background).MarginBackground(background)
+	}
+
+	// * Apply styling
+	slideContent := style.Render(body)
+	current, total := frame.Current, frame.Total
+	data := chromeData(frame)
+
+	// * Add header if configured
+	if r.header != nil {
+		header, err := r.renderBar(r.header, data, frame.Slide.Metadata.HideHeader, background)
+		if err != nil {
+			return "", err
+		}
+		slideContent = header + "\n" + slideContent
+	}
+
 	// * Add Progress bar if enabled
 	if r.config.Theme.ShowProgress {
-		progress := r.renderProgressBar(current, total)
+		progress := r.renderProgressBar(current, total, background)
 		slideContent = slideContent + "\n" + progress
 	}
 
-	// * Add slide number if enabled
-	if r.config.Theme.ShowSlideNum {
-		slideNum := r.renderSlideNumber(current, total)
-		slideContent = slideContent + "\n" + slideNum
+	// * Add footer, which shows the slide number unless configured otherwise
+	if r.footer != nil {
+		footer, err := r.renderBar(r.footer, data, frame.Slide.Metadata.HideFooter, background)
+		if err != nil {
+			return "", err
+		}
+		slideContent = slideContent + "\n" + footer
 	}
 
 	return slideContent, nil
 }
 
-func (r *Renderer) RenderTitle(title, subtitle string) string {
-	titleStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("63")).
-		Width(r.width).
-		Align(lipgloss.Center).
-		MarginTop(r.height / 3)
+// RenderPreview renders a fragment state of a slide into a width x height
+// box without touching the slide cache, so it can be used for side panes
+func (r *Renderer) RenderPreview(slide *models.Slide, fragment, width, height int) (string, error) {
+	if width <= 0 || height <= 0 {
+		return "", nil
+	}
 
-	subtitleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
-		Width(r.width).
-		Align(lipgloss.Center).
-		MarginTop(1)
+	rendered, err := r.renderCompact(slide, slide.FragmentContent(fragment), width)
+	if err != nil {
+		return "", err
+	}
+
+	// * Clip to the preview box
+	return lipgloss.NewStyle().
+		MaxWidth(width).
+		MaxHeight(height).
+		Render(strings.Trim(rendered, "\n")), nil
+}
+
+// RenderThumbnail renders the fully revealed slide into a width x height
+// box with blank lines removed, so more of the slide fits in a grid cell
+func (r *Renderer) RenderThumbnail(slide *models.Slide, width, height int) (string, error) {
+	if width <= 0 || height <= 0 {
+		return "", nil
+	}
 
-	var content string
-	content += titleStyle.Render(title)
-	if subtitle != "" {
-		content += "\n" + subtitleStyle.Render(subtitle)
+	key := thumbnailKey{slide: slide, width: width, height: height}
+	if thumbnail, ok := r.thumbnails[key]; ok {
+		return thumbnail, nil
 	}
 
-	return content
+	rendered, err := r.renderCompact(slide, slide.RawContent, width)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, height)
+	for line := range strings.SplitSeq(rendered, "\n") {
+		if strings.TrimSpace(xansi.Strip(line)) == "" {
+			continue
+		}
+		lines = append(lines, xansi.Truncate(line, width, "…"))
+		if len(lines) == height {
+			break
+		}
+	}
+
+	thumbnail := strings.Join(lines, "\n")
+	r.thumbnails[key] = thumbnail
+
+	return thumbnail, nil
+}
+
+// renderCompact renders markdown with a renderer sized for a small box,
+// with columns stacked as there is no room for them side by side
+func (r *Renderer) renderCompact(slide *models.Slide, content string, width int) (string, error) {
+	gr, err := r.rendererFor(slide, max(width-2, 10))
+	if err != nil {
+		return "", err
+	}
+
+	rendered, err := gr.Render(StackColumns(StripMetadataComments(content)))
+	if err != nil {
+		return "", fmt.Errorf("failed to render markdown: %w", err)
+	}
+
+	return rendered, nil
+}
+
+// RenderTitle lays out a rendered cover or closing slide, centering each
+// line across the screen and the whole block vertically
+func (r *Renderer) RenderTitle(body string) string {
+	width := r.style.GetWidth() - r.style.GetHorizontalPadding()
+
+	lines := strings.Split(strings.Trim(body, "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(xansi.Strip(lines[len(lines)-1])) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i, line := range lines {
+		// * Cut the line down to its visible text, dropping glamour's indent and padding
+		plain := xansi.Strip(line)
+		left := xansi.StringWidth(plain) - xansi.StringWidth(strings.TrimLeft(plain, " "))
+		right := xansi.StringWidth(strings.TrimRight(plain, " "))
+		if right <= left {
+			lines[i] = ""
+			continue
+		}
+
+		text := xansi.Cut(line, left, right)
+		lines[i] = strings.Repeat(" ", max((width-(right-left))/2, 0)) + text + "\x1b[0m"
+	}
+
+	// ? Keep the block in the middle of the screen when it fits
+	top := max((r.viewportHeight()-len(lines))/2, 0)
+
+	return strings.Repeat("\n", top) + strings.Join(lines, "\n")
 }
 
 func (r *Renderer) RenderError(err error) string {
 	errorStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("196")).
+		Foreground(r.scheme.Error).
 		Bold(true).
 		Width(r.width).
 		Align(lipgloss.Center).
@@ -175,16 +590,29 @@ func (r *Renderer) RenderError(err error) string {
 	return errorStyle.Render(fmt.Sprintf("Error: %s", err.Error()))
 }
 
+// SetColorScheme sets the colors the chrome is drawn in
+func (r *Renderer) SetColorScheme(scheme theme.ColorScheme) {
+	r.scheme = scheme
+}
+
+// SetHighlight sets the pattern highlighted in rendered slides, nil clears it
+func (r *Renderer) SetHighlight(re *regexp.Regexp) {
+	r.highlight = re
+}
+
 func (r *Renderer) Resize(width, height int) {
 	r.width = width
 	r.height = height
 
+	// * Leave room for the header, progress bar and footer
 	r.style = r.style.
 		Width(width - (r.config.Presentation.Margin * 2)).
-		Height(height - (r.config.Presentation.Margin * 2))
+		Height(max(height-(r.config.Presentation.Margin*2)-r.chromeLines(), 0))
 }
 
 func (r *Renderer) ClearCache(presentation *models.Presentation) {
+	clear(r.thumbnails)
+
 	for i := 0; i < presentation.SlideCount(); i++ {
 		if slide, err := presentation.GetSlide(i); err == nil {
 			slide.ClearCache()
@@ -0,0 +1,51 @@
+package display
+
+import (
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// * Columns the terminal is indented by, lining it up with the slide text
+const terminalIndent = 2
+
+// TerminalSize returns the columns and rows the terminal of a slide gets,
+// filling the room left below the slide content
+func (r *Renderer) TerminalSize(slide *models.Slide, fragment int) (int, int) {
+	lines := 0
+	if body, err := r.RenderFragment(slide, fragment); err == nil {
+		lines = strings.Count(body, "\n") + 1
+	}
+
+	// ? Leave room for the blank line above the terminal and its border
+	width := r.style.GetWidth() - r.style.GetHorizontalPadding() - 2*terminalIndent - 2
+	height := r.viewportHeight() - lines - 3
+
+	return max(width, 10), max(height, 3)
+}
+
+// terminalPane frames the terminal screen of a slide, or a hint to start it
+func (r *Renderer) terminalPane(frame Frame) string {
+	width, height := r.TerminalSize(frame.Slide, frame.Fragment)
+
+	screen := frame.Terminal
+	if screen == "" {
+		screen = lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center,
+			lipgloss.NewStyle().Foreground(r.scheme.Muted).Render("Press ctrl+t to start the terminal"))
+	}
+
+	border := r.scheme.Border
+	if frame.TerminalFocused {
+		border = r.scheme.Primary
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		MarginLeft(terminalIndent).
+		Width(width).
+		Height(height).
+		MaxHeight(height + 2).
+		Render(screen)
+}
@@ -0,0 +1,116 @@
+package display
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// * Transition animates the change from one slide to the next
+type Transition string
+
+const (
+	TransitionNone       Transition = "none"
+	TransitionFade       Transition = "fade"
+	TransitionSlideLeft  Transition = "slide-left"
+	TransitionSlideRight Transition = "slide-right"
+	TransitionWipe       Transition = "wipe"
+)
+
+const (
+	faintOn  = "\x1b[2m"
+	resetAll = "\x1b[0m"
+)
+
+// * Transitions lists the transitions slides may name
+var Transitions = []Transition{
+	TransitionNone, TransitionFade, TransitionSlideLeft, TransitionSlideRight, TransitionWipe,
+}
+
+// ParseTransition looks up a transition by name
+func ParseTransition(name string) (Transition, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, transition := range Transitions {
+		if string(transition) == name {
+			return transition, true
+		}
+	}
+	return TransitionNone, false
+}
+
+// Reverse returns the transition that plays the other way, used when going
+// back through the deck
+func (t Transition) Reverse() Transition {
+	switch t {
+	case TransitionSlideLeft:
+		return TransitionSlideRight
+	case TransitionSlideRight:
+		return TransitionSlideLeft
+	}
+	return t
+}
+
+// ComposeTransition draws one frame of a transition between two rendered
+// screens. Progress runs from 0, showing only from, to 1, showing only to.
+func ComposeTransition(t Transition, from, to string, progress float64, width, height int) string {
+	progress = min(max(progress, 0), 1)
+	fromLines := screenLines(from, width, height)
+	toLines := screenLines(to, width, height)
+
+	// * Columns of the incoming screen that are visible
+	offset := int(progress * float64(width))
+
+	lines := make([]string, height)
+	for i := range lines {
+		switch t {
+		case TransitionSlideLeft:
+			// ? The incoming screen pushes in from the right
+			lines[i] = cut(fromLines[i], offset, width) + cut(toLines[i], 0, offset)
+		case TransitionSlideRight:
+			// ? The incoming screen pushes in from the left
+			lines[i] = cut(toLines[i], width-offset, width) + cut(fromLines[i], 0, width-offset)
+		case TransitionWipe:
+			lines[i] = cut(toLines[i], 0, offset) + cut(fromLines[i], offset, width)
+		case TransitionFade:
+			// ? Dim the outgoing screen, then bring in the incoming one dimmed
+			if progress < 0.5 {
+				lines[i] = faint(fromLines[i])
+			} else {
+				lines[i] = faint(toLines[i])
+			}
+		default:
+			lines[i] = toLines[i]
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// screenLines splits a rendered screen into exactly height lines, each
+// padded to width so the columns of both screens line up
+func screenLines(screen string, width, height int) []string {
+	lines := strings.Split(screen, "\n")
+	result := make([]string, height)
+	for i := range result {
+		if i < len(lines) {
+			result[i] = ansi.Truncate(lines[i], width, "")
+		}
+		if pad := width - ansi.StringWidth(result[i]); pad > 0 {
+			result[i] += strings.Repeat(" ", pad)
+		}
+	}
+	return result
+}
+
+// cut returns the columns [left, right) of a line, closing any styling so it
+// does not leak into the next piece
+func cut(line string, left, right int) string {
+	if right <= left {
+		return ""
+	}
+	return ansi.Cut(line, left, right) + resetAll
+}
+
+func faint(line string) string {
+	return faintOn + strings.ReplaceAll(line, resetAll, resetAll+faintOn) + resetAll
+}
@@ -0,0 +1,123 @@
+package display
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestComposeTransition(t *testing.T) {
+	// * The outgoing screen is shorter than the window, the incoming one
+	// * taller and wider
+	wide := struct{ from, to string }{"abcdefgh\nij", "12345678\n123456789ABC\nxyz\nextra"}
+	// * A single line window where the incoming screen is cut
+	narrow := struct{ from, to string }{"ab", "wxyz12"}
+
+	tests := []struct {
+		name       string
+		transition Transition
+		from, to   string
+		width      int
+		height     int
+		progress   float64
+		expected   string
+	}{
+		{"Slide left first frame", TransitionSlideLeft, wide.from, wide.to, 8, 3, 0, "abcdefgh\nij      \n        "},
+		{"Slide left middle frame", TransitionSlideLeft, wide.from, wide.to, 8, 3, 0.5, "efgh1234\n    1234\n    xyz "},
+		{"Slide left last frame", TransitionSlideLeft, wide.from, wide.to, 8, 3, 1, "12345678\n12345678\nxyz     "},
+		{"Slide left narrow", TransitionSlideLeft, narrow.from, narrow.to, 4, 1, 0.25, "b  w"},
+
+		{"Slide right first frame", TransitionSlideRight, wide.from, wide.to, 8, 3, 0, "abcdefgh\nij      \n        "},
+		{"Slide right middle frame", TransitionSlideRight, wide.from, wide.to, 8, 3, 0.5, "5678abcd\n5678ij  \n        "},
+		{"Slide right last frame", TransitionSlideRight, wide.from, wide.to, 8, 3, 1, "12345678\n12345678\nxyz     "},
+		{"Slide right narrow", TransitionSlideRight, narrow.from, narrow.to, 4, 1, 0.25, "zab "},
+
+		{"Wipe first frame", TransitionWipe, wide.from, wide.to, 8, 3, 0, "abcdefgh\nij      \n        "},
+		{"Wipe middle frame", TransitionWipe, wide.from, wide.to, 8, 3, 0.5, "1234efgh\n1234    \nxyz     "},
+		{"Wipe last frame", TransitionWipe, wide.from, wide.to, 8, 3, 1, "12345678\n12345678\nxyz     "},
+		{"Wipe narrow", TransitionWipe, narrow.from, narrow.to, 4, 1, 0.25, "wb  "},
+
+		{"Fade first frame", TransitionFade, wide.from, wide.to, 8, 3, 0, "abcdefgh\nij      \n        "},
+		{"Fade middle frame", TransitionFade, wide.from, wide.to, 8, 3, 0.5, "12345678\n12345678\nxyz     "},
+		{"Fade last frame", TransitionFade, wide.from, wide.to, 8, 3, 1, "12345678\n12345678\nxyz     "},
+		{"Fade narrow", TransitionFade, narrow.from, narrow.to, 4, 1, 0.25, "ab  "},
+
+		{"None first frame", TransitionNone, wide.from, wide.to, 8, 3, 0, "12345678\n12345678\nxyz     "},
+		{"None last frame", TransitionNone, narrow.from, narrow.to, 4, 1, 1, "wxyz"},
+
+		{"Progress clamped below", TransitionWipe, narrow.from, narrow.to, 4, 1, -1, "ab  "},
+		{"Progress clamped above", TransitionWipe, narrow.from, narrow.to, 4, 1, 2, "wxyz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := ComposeTransition(tt.transition, tt.from, tt.to, tt.progress, tt.width, tt.height)
+			if got := ansi.Strip(frame); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+
+			lines := strings.Split(frame, "\n")
+			if len(lines) != tt.height {
+				t.Errorf("Expected %d lines, got %d", tt.height, len(lines))
+			}
+			for i, line := range lines {
+				if got := ansi.StringWidth(line); got != tt.width {
+					t.Errorf("Expected line %d to be %d wide, got %d", i, tt.width, got)
+				}
+			}
+		})
+	}
+}
+
+func TestComposeTransitionStyles(t *testing.T) {
+	from := "\x1b[31mabcdefgh\x1b[0m"
+	to := "\x1b[32m12345678\x1b[0m"
+
+	t.Run("Fade dims the screen", func(t *testing.T) {
+		frame := ComposeTransition(TransitionFade, from, to, 0.25, 8, 1)
+		if !strings.HasPrefix(frame, faintOn) {
+			t.Errorf("Expected the frame to start faint, got %q", frame)
+		}
+		// ? A reset inside the line must not end the dimming early
+		if !strings.Contains(frame, resetAll+faintOn) {
+			t.Errorf("Expected faint to be restored after each reset, got %q", frame)
+		}
+	})
+
+	t.Run("Pieces do not leak styles", func(t *testing.T) {
+		frame := ComposeTransition(TransitionWipe, from, to, 0.5, 8, 1)
+		pieces := strings.Split(frame, resetAll)
+		if !strings.HasSuffix(frame, resetAll) {
+			t.Errorf("Expected the frame to end with a reset, got %q", frame)
+		}
+		if !strings.Contains(pieces[0], "1234") || strings.Contains(pieces[0], "efgh") {
+			t.Errorf("Expected the incoming piece to be closed before the outgoing one, got %q", frame)
+		}
+	})
+}
+
+func TestParseTransition(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Transition
+		ok       bool
+	}{
+		{"fade", TransitionFade, true},
+		{" Slide-Left ", TransitionSlideLeft, true},
+		{"spin", TransitionNone, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseTransition(tt.name)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("Expected %q %v, got %q %v", tt.expected, tt.ok, got, ok)
+			}
+		})
+	}
+
+	if TransitionSlideLeft.Reverse() != TransitionSlideRight || TransitionWipe.Reverse() != TransitionWipe {
+		t.Error("Expected slides to reverse direction and other transitions to stay")
+	}
+}
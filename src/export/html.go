@@ -0,0 +1,112 @@
+package export
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+)
+
+//go:embed templates/presentation.html
+var htmlTemplate string
+
+// * HTMLOptions configure a standalone HTML export
+type HTMLOptions struct {
+	Colors theme.ColorScheme
+	// CodeStyle is the chroma style used for code blocks
+	CodeStyle string
+}
+
+// * htmlSlide is the template view of one slide
+type htmlSlide struct {
+	Number int
+	// Body holds the slide content, with each step in fragment elements
+	Body template.HTML
+	// Steps is the number of steps the slide reveals
+	Steps int
+	Notes string
+}
+
+// * htmlDocument is the template view of the whole deck
+type htmlDocument struct {
+	Title  string
+	Author string
+	Date   string
+	Colors map[string]string
+	Slides []htmlSlide
+}
+
+func colorVariables(scheme theme.ColorScheme) map[string]string {
+	return map[string]string{
+		"primary":    theme.Hex(scheme.Primary),
+		"secondary":  theme.Hex(scheme.Secondary),
+		"background": theme.Hex(scheme.Background),
+		"foreground": theme.Hex(scheme.Foreground),
+		"accent":     theme.Hex(scheme.Accent),
+		"muted":      theme.Hex(scheme.Muted),
+		"border":     theme.Hex(scheme.Border),
+		"progress":   theme.Hex(scheme.ProgressBar),
+	}
+}
+
+func documentTitle(presentation *models.Presentation) string {
+	if presentation.Title != "" {
+		return presentation.Title
+	}
+
+	for _, slide := range presentation.Slides {
+		if title := slide.Title(); title != "" {
+			return title
+		}
+	}
+
+	return "Slate Presentation"
+}
+
+// HTML writes the presentation as a single self-contained HTML file with
+// inline styles, images and keyboard navigation, so it works offline
+func HTML(w io.Writer, presentation *models.Presentation, opts HTMLOptions) error {
+	tmpl, err := template.New("presentation").Parse(htmlTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse HTML template: %w", err)
+	}
+
+	converter := newMarkdownConverter(opts.CodeStyle, filepath.Dir(presentation.FilePath))
+
+	doc := htmlDocument{
+		Title:  documentTitle(presentation),
+		Author: presentation.Author,
+		Colors: colorVariables(opts.Colors),
+		Slides: make([]htmlSlide, 0, presentation.SlideCount()),
+	}
+	if !presentation.Date.IsZero() {
+		doc.Date = presentation.Date.Format("2006-01-02")
+	}
+
+	for i, slide := range presentation.Slides {
+		// * The slide is converted whole, so pauses inside columns keep the
+		// * columns together and only hide what comes after them
+		body, steps, err := converter.ConvertSlide(slide.Content(), slide.Metadata.Incremental)
+		if err != nil {
+			return fmt.Errorf("slide %d: %w", i+1, err)
+		}
+
+		doc.Slides = append(doc.Slides, htmlSlide{
+			Number: i + 1,
+			// #nosec G203 -- HTML comes from the goldmark renderer, which omits raw HTML
+			Body:  template.HTML(body),
+			Steps: steps,
+			Notes: slide.Metadata.Notes,
+		})
+	}
+
+	if err := tmpl.Execute(w, doc); err != nil {
+		return fmt.Errorf("failed to write HTML: %w", err)
+	}
+
+	return nil
+}
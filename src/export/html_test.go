@@ -0,0 +1,155 @@
+package export
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const testDeck = `---
+title: Export Test
+author: Ada
+---
+
+# Welcome
+
+Intro text
+
+<!-- @notes: mention the secret roadmap -->
+
+---
+
+# Code
+
+` + "```go\nfunc main() {}\n```" + `
+
+---
+
+# Steps
+
+- One
+
+<!-- @pause -->
+
+- Two
+`
+
+func TestHTML(t *testing.T) {
+	presentation, err := data.ParseFromString(testDeck, "test.md")
+	if err != nil {
+		t.Fatalf("Expected deck to parse, got %v", err)
+	}
+
+	colors := theme.ColorScheme{Primary: lipgloss.Color("#ff0000"), Background: lipgloss.Color("16")}
+
+	var out bytes.Buffer
+	if err := HTML(&out, presentation, HTMLOptions{Colors: colors, CodeStyle: "monokai"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	html := out.String()
+
+	t.Run("Color variables", func(t *testing.T) {
+		for _, variable := range []string{"--primary: #ff0000;", "--background: #000000;", "var(--primary)"} {
+			if !strings.Contains(html, variable) {
+				t.Errorf("Expected HTML to contain %q", variable)
+			}
+		}
+	})
+
+	t.Run("One section per slide", func(t *testing.T) {
+		if got := strings.Count(html, `<section class="slide"`); got != presentation.SlideCount() {
+			t.Errorf("Expected %d slide sections, got %d", presentation.SlideCount(), got)
+		}
+	})
+
+	t.Run("Fragments", func(t *testing.T) {
+		steps := html[strings.Index(html, `id="slide-3"`):]
+		steps = steps[:strings.Index(steps, "</section>")]
+		if !strings.Contains(steps, `data-steps="2"`) {
+			t.Error("Expected the last slide to have 2 steps")
+		}
+		for _, step := range []string{`data-step="0"`, `data-step="1"`} {
+			if !strings.Contains(steps, step) {
+				t.Errorf("Expected a fragment with %s on the last slide", step)
+			}
+		}
+	})
+
+	t.Run("Code highlighted", func(t *testing.T) {
+		if !regexp.MustCompile(`<span style="[^"]*color:#[0-9a-f]{6}[^"]*">func</span>`).MatchString(html) {
+			t.Error("Expected the func keyword in an inline styled span")
+		}
+	})
+
+	t.Run("Notes kept out of slides", func(t *testing.T) {
+		if got := strings.Count(html, "secret roadmap"); got != 1 {
+			t.Errorf("Expected the notes once, got %d times", got)
+		}
+		if !strings.Contains(html, `<aside class="notes">mention the secret roadmap</aside>`) {
+			t.Error("Expected the notes in the hidden notes aside")
+		}
+		if strings.Contains(html, "@notes") {
+			t.Error("Expected metadata comments to be stripped")
+		}
+	})
+
+	t.Run("Title and author", func(t *testing.T) {
+		if !strings.Contains(html, "<title>Export Test</title>") || !strings.Contains(html, `content="Ada"`) {
+			t.Error("Expected the deck title and author in the head")
+		}
+	})
+}
+
+func TestDocumentTitle(t *testing.T) {
+	presentation, _ := data.ParseFromString("# First Heading\n\n---\n\n# Second", "test.md")
+	if got := documentTitle(presentation); got != "First Heading" {
+		t.Errorf("Expected the first slide title, got %q", got)
+	}
+
+	presentation, _ = data.ParseFromString("no headings here", "test.md")
+	if got := documentTitle(presentation); got != "Slate Presentation" {
+		t.Errorf("Expected the fallback title, got %q", got)
+	}
+}
+
+func TestHTMLInlinesImages(t *testing.T) {
+	dir := t.TempDir()
+	image := []byte("\x89PNG\r\n\x1a\nnot really")
+	if err := os.MkdirAll(filepath.Join(dir, "img"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "img", "dot.png"), image, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	content := "# Images\n\n![dot](img/dot.png)\n\n![remote](https://example.com/a.png)\n\n![missing](nope.png)\n"
+	presentation, err := data.ParseFromString(content, filepath.Join(dir, "deck.md"))
+	if err != nil {
+		t.Fatalf("Expected deck to parse, got %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := HTML(&out, presentation, HTMLOptions{CodeStyle: "github"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	html := out.String()
+
+	expected := []string{
+		`src="data:image/png;base64,` + base64.StdEncoding.EncodeToString(image) + `"`,
+		`src="https://example.com/a.png"`,
+		`src="nope.png"`,
+	}
+	for _, part := range expected {
+		if !strings.Contains(html, part) {
+			t.Errorf("Expected HTML to contain %q", part)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package export
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// * Media types of the image formats browsers show from a data URI
+var imageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".svg":  "image/svg+xml",
+}
+
+// * codeBlockRenderer highlights fenced code with chroma using inline styles
+// * so the exported file needs no external stylesheet
+type codeBlockRenderer struct {
+	style *chroma.Style
+}
+
+func (r *codeBlockRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+func (r *codeBlockRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	block := node.(*ast.FencedCodeBlock)
+	language := string(block.Language(source))
+	code := blockText(block, source)
+
+	iterator, err := lexerFor(language, code).Tokenise(nil, code)
+	if err != nil {
+		return ast.WalkStop, fmt.Errorf("failed to tokenise code block: %w", err)
+	}
+
+	formatter := chromahtml.New(chromahtml.WithClasses(false), chromahtml.TabWidth(4))
+	if err := formatter.Format(w, r.style, iterator); err != nil {
+		return ast.WalkStop, fmt.Errorf("failed to highlight code block: %w", err)
+	}
+
+	return ast.WalkSkipChildren, nil
+}
+
+func lexerFor(language, code string) chroma.Lexer {
+	var lexer chroma.Lexer
+	if language != "" {
+		lexer = lexers.Get(language)
+	}
+	if lexer == nil {
+		lexer = lexers.Analyse(code)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	return chroma.Coalesce(lexer)
+}
+
+func blockText(node ast.Node, source []byte) string {
+	var buf strings.Builder
+
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		buf.Write(segment.Value(source))
+	}
+
+	return buf.String()
+}
+
+// * imageInliner embeds local images as data URIs, so the exported file
+// * shows them wherever it is opened. Remote images stay links.
+type imageInliner struct {
+	dir string
+}
+
+func (t *imageInliner) Transform(doc *ast.Document, _ text.Reader, _ parser.Context) {
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if image, ok := node.(*ast.Image); ok && entering {
+			if uri, ok := t.dataURI(string(image.Destination)); ok {
+				image.Destination = []byte(uri)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// dataURI reads a local image, relative to the deck, into a data URI.
+// Images that cannot be read are left for the browser to look up.
+func (t *imageInliner) dataURI(src string) (string, bool) {
+	if strings.Contains(src, "://") || strings.HasPrefix(src, "data:") {
+		return "", false
+	}
+
+	mediaType, ok := imageTypes[strings.ToLower(filepath.Ext(src))]
+	if !ok {
+		return "", false
+	}
+
+	path := filepath.FromSlash(src)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(t.dir, path)
+	}
+
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", false
+	}
+
+	return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(content), true
+}
+
+// * markdownConverter turns slide markdown into HTML
+type markdownConverter struct {
+	md goldmark.Markdown
+}
+
+// newMarkdownConverter converts markdown with code highlighted in codeStyle
+// and local images read from dir
+func newMarkdownConverter(codeStyle, dir string) *markdownConverter {
+	style := styles.Get(codeStyle)
+
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(
+			parser.WithASTTransformers(util.Prioritized(&imageInliner{dir: dir}, 100)),
+		),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(&codeBlockRenderer{style: style}, 100)),
+		),
+	)
+
+	return &markdownConverter{md: md}
+}
+
+func (c *markdownConverter) Convert(content string) (string, error) {
+	var buf bytes.Buffer
+	if err := c.md.Convert([]byte(content), &buf); err != nil {
+		return "", fmt.Errorf("failed to convert markdown: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// ConvertSlide converts slide markdown like Convert, laying out rows of
+// columns side by side. The content revealed in each step is wrapped in a
+// fragment element numbered with its step, counted across the whole slide,
+// so pauses inside columns reveal in place.
+func (c *markdownConverter) ConvertSlide(content string, incremental bool) (string, int, error) {
+	w := &fragmentWriter{converter: c, incremental: incremental}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	next := 0
+
+	for _, group := range data.ParseColumns(content) {
+		if err := w.text(strings.Join(lines[next:group.Line-1], "\n")); err != nil {
+			return "", 0, err
+		}
+
+		// ? The columns are drawn from the step they open in
+		w.raw(`<div class="columns">`)
+		for _, column := range group.Columns {
+			if column.Width > 0 {
+				w.raw(fmt.Sprintf(`<div class="column" style="flex: 0 1 %d%%">`, column.Width))
+			} else {
+				w.raw(`<div class="column">`)
+			}
+			if err := w.text(column.Content); err != nil {
+				return "", 0, err
+			}
+			w.raw("</div>")
+		}
+		w.raw("</div>\n")
+
+		next = group.EndLine
+	}
+
+	if err := w.text(strings.Join(lines[next:], "\n")); err != nil {
+		return "", 0, err
+	}
+
+	return w.out.String(), w.step + 1, nil
+}
+
+// * fragmentWriter converts the content of one slide, step by step
+type fragmentWriter struct {
+	converter   *markdownConverter
+	incremental bool
+	out         strings.Builder
+	// step is the reveal step of the content being written
+	step int
+	// shown is set once the current step has content
+	shown bool
+	// pending holds lines of the current step that are not converted yet
+	pending []string
+}
+
+// text writes markdown, starting a new step at each pause marker and, on
+// incremental slides, at each top-level list item
+func (w *fragmentWriter) text(content string) error {
+	for _, token := range data.Tokenize(content) {
+		if data.IsPauseMarker(token) {
+			if err := w.nextStep(); err != nil {
+				return err
+			}
+			continue
+		}
+		if w.incremental && data.IsTopLevelListItem(token) {
+			if err := w.nextStep(); err != nil {
+				return err
+			}
+		}
+		w.pending = append(w.pending, token.Lines...)
+	}
+	return w.flush()
+}
+
+// raw writes HTML that belongs to the current step
+func (w *fragmentWriter) raw(html string) {
+	w.out.WriteString(html)
+	w.shown = true
+}
+
+func (w *fragmentWriter) nextStep() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+	// ? Steps with nothing to show are not counted, as in the terminal
+	if w.shown {
+		w.step++
+		w.shown = false
+	}
+	return nil
+}
+
+func (w *fragmentWriter) flush() error {
+	source := display.StripMetadataComments(strings.Join(w.pending, "\n"))
+	w.pending = nil
+	if strings.TrimSpace(source) == "" {
+		return nil
+	}
+
+	converted, err := w.converter.Convert(source)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(&w.out, `<div class="fragment" data-step="%d">%s</div>`, w.step, converted)
+	w.shown = true
+	return nil
+}
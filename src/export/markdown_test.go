@@ -0,0 +1,103 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	converter := newMarkdownConverter("monokai", "")
+
+	tests := []struct {
+		name     string
+		content  string
+		contains []string
+		excludes []string
+	}{
+		{"Heading", "# Title", []string{"<h1>Title</h1>"}, nil},
+		{"Highlighted code", "```go\nreturn nil\n```", []string{"<pre", `<span style="`, "return</span>"}, []string{"<code class="}},
+		{"Guessed language", "```\n#!/bin/sh\necho hi\n```", []string{"<pre", `<span style="`}, nil},
+		{"Table", "| A | B |\n|---|---|\n| 1 | 2 |", []string{"<table>", "<td>1</td>"}, nil},
+		{"Raw HTML omitted", "<script>alert(1)</script>", []string{"raw HTML omitted"}, []string{"<script>"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := converter.Convert(tt.content)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			for _, part := range tt.contains {
+				if !strings.Contains(got, part) {
+					t.Errorf("Expected %q in %q", part, got)
+				}
+			}
+			for _, part := range tt.excludes {
+				if strings.Contains(got, part) {
+					t.Errorf("Expected no %q in %q", part, got)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertSlide(t *testing.T) {
+	converter := newMarkdownConverter("monokai", "")
+
+	got, steps, err := converter.ConvertSlide("# Title\n\n::: columns\n::: column 40%\nLeft\n:::\n::: column\nRight\n:::\n:::\n\nAfter", false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if steps != 1 {
+		t.Errorf("Expected 1 step, got %d", steps)
+	}
+
+	expected := []string{
+		"<h1>Title</h1>",
+		`<div class="columns"><div class="column" style="flex: 0 1 40%"><div class="fragment" data-step="0"><p>Left</p>`,
+		`<div class="column"><div class="fragment" data-step="0"><p>Right</p>`,
+		"<p>After</p>",
+	}
+	last := 0
+	for _, part := range expected {
+		index := strings.Index(got[last:], part)
+		if index < 0 {
+			t.Fatalf("Expected %q after position %d in %q", part, last, got)
+		}
+		last += index + len(part)
+	}
+}
+
+func TestConvertSlidePauseInColumns(t *testing.T) {
+	converter := newMarkdownConverter("monokai", "")
+
+	got, steps, err := converter.ConvertSlide("Intro\n\n::: columns\n::: column\nLeft\n\n<!-- @pause -->\n\nMore left\n:::\n::: column\n<!-- @pause -->\nRight\n:::\n:::", false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if steps != 3 {
+		t.Errorf("Expected 3 steps, got %d", steps)
+	}
+	if count := strings.Count(got, `<div class="columns">`); count != 1 {
+		t.Errorf("Expected 1 columns container, got %d in %q", count, got)
+	}
+	if strings.Contains(got, ":::") {
+		t.Errorf("Expected no column markers in %q", got)
+	}
+
+	expected := []string{
+		`<div class="fragment" data-step="0"><p>Intro</p>`,
+		`<div class="fragment" data-step="0"><p>Left</p>`,
+		`<div class="fragment" data-step="1"><p>More left</p>`,
+		`<div class="fragment" data-step="2"><p>Right</p>`,
+	}
+	last := 0
+	for _, part := range expected {
+		index := strings.Index(got[last:], part)
+		if index < 0 {
+			t.Fatalf("Expected %q after position %d in %q", part, last, got)
+		}
+		last += index + len(part)
+	}
+}
@@ -0,0 +1,260 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	// * A4 in points
+	a4Short = 595.0
+	a4Long  = 842.0
+
+	pageMargin   = 36.0
+	footerHeight = 18.0
+	notesGap     = 24.0
+	notesScale   = 0.65
+)
+
+// * PDFOptions configure a PDF handout export
+type PDFOptions struct {
+	Colors theme.ColorScheme
+	// CodeStyle is the chroma style used for code blocks
+	CodeStyle string
+	// Notes prints speaker notes below each slide, one slide per page
+	Notes bool
+	// PerPage prints several slides on each page
+	PerPage int
+}
+
+// * pdfExporter lays slides out onto the pages of one document
+type pdfExporter struct {
+	presentation *models.Presentation
+	opts         PDFOptions
+	palette      pdfPalette
+	markdown     goldmark.Markdown
+	doc          *pdfDocument
+}
+
+func newPalette(scheme theme.ColorScheme) pdfPalette {
+	return pdfPalette{
+		Text:      parseHexColor("#222222"),
+		Heading:   parseHexColor(theme.Hex(scheme.Primary)),
+		Secondary: parseHexColor(theme.Hex(scheme.Secondary)),
+		Accent:    parseHexColor(theme.Hex(scheme.Accent)),
+		Muted:     parseHexColor("#666666"),
+		Border:    parseHexColor("#cccccc"),
+		Page:      rgb{R: 1, G: 1, B: 1},
+		CodeBack:  parseHexColor("#f5f5f5"),
+	}
+}
+
+// PDF writes the presentation as a printable handout. Text uses the
+// standard PDF fonts, so the file renders anywhere without embedding.
+func PDF(w io.Writer, presentation *models.Presentation, opts PDFOptions) error {
+	if opts.Notes && opts.PerPage > 1 {
+		return fmt.Errorf("speaker notes can only be printed with one slide per page")
+	}
+
+	e := &pdfExporter{
+		presentation: presentation,
+		opts:         opts,
+		palette:      newPalette(opts.Colors),
+		markdown:     goldmark.New(goldmark.WithExtensions(extension.GFM)),
+	}
+
+	switch {
+	case opts.Notes:
+		e.doc = newPDFDocument(a4Short, a4Long)
+		e.notesPages()
+	case opts.PerPage > 1:
+		e.doc = newPDFDocument(a4Short, a4Long)
+		e.gridPages(opts.PerPage)
+	default:
+		e.doc = newPDFDocument(a4Long, a4Short)
+		e.slidePages()
+	}
+
+	e.doc.info["Title"] = documentTitle(presentation)
+	e.doc.info["Author"] = presentation.Author
+	e.doc.info["Subject"] = fmt.Sprintf("%d slides", presentation.SlideCount())
+	e.footers()
+
+	if err := e.doc.write(w); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+
+	return nil
+}
+
+// layoutSlide flows a slide onto the slide canvas, shrinking the text until
+// it fits
+func (e *pdfExporter) layoutSlide(slide *models.Slide) *slideLayout {
+	// ? Columns are stacked, as slides are laid out as a single flow
+	source := []byte(display.StackColumns(display.StripMetadataComments(slide.Content())))
+	document := e.markdown.Parser().Parse(text.NewReader(source))
+
+	var layout *slideLayout
+	for scale := 1.0; ; scale *= 0.85 {
+		layout = newSlideLayout(slideWidth, slideHeight, scale, e.palette, styles.Get(e.opts.CodeStyle), source)
+		layout.blocks(document, 0, e.palette.Text)
+
+		if !layout.overflowed() || scale*0.85 < minFontScale {
+			return layout
+		}
+	}
+}
+
+// drawSlide places a slide on the page with its top-left corner at x, top
+func (e *pdfExporter) drawSlide(page *pdfCanvas, slide *models.Slide, x, top, width float64) float64 {
+	scale := width / slideWidth
+	height := slideHeight * scale
+	y := e.doc.height - top - height
+
+	page.rect(x, y, width, height, e.palette.Page)
+	page.strokeRect(x, y, width, height, 0.75, e.palette.Border)
+
+	page.transform(scale, x, y)
+	page.buf.Write(e.layoutSlide(slide).canvas.buf.Bytes())
+	page.restore()
+
+	return height
+}
+
+func (e *pdfExporter) slidePages() {
+	width := min(e.doc.width-2*pageMargin, (e.doc.height-2*pageMargin-footerHeight)*slideWidth/slideHeight)
+	x := (e.doc.width - width) / 2
+
+	for _, slide := range e.presentation.Slides {
+		e.drawSlide(e.doc.addPage(), slide, x, pageMargin, width)
+	}
+}
+
+func (e *pdfExporter) notesPages() {
+	width := e.doc.width - 2*pageMargin
+
+	for _, slide := range e.presentation.Slides {
+		page := e.doc.addPage()
+		height := e.drawSlide(page, slide, pageMargin, pageMargin, width)
+
+		notes := slide.Metadata.Notes
+		if notes == "" {
+			notes = "No notes for this slide."
+		}
+		paragraphs := strings.Split(notes, "\n")
+
+		// * Notes flow in their own region below the slide, shrinking until
+		// * they fit as slides do
+		region := e.doc.height - (pageMargin + height + notesGap) - pageMargin - footerHeight
+		scale := notesScale
+		layout, rest := e.layoutNotes("Notes", paragraphs, width, region, scale)
+		for (len(rest) > 0 || layout.overflowed()) && scale*0.85 >= minFontScale {
+			scale *= 0.85
+			layout, rest = e.layoutNotes("Notes", paragraphs, width, region, scale)
+		}
+		e.placeNotes(page, layout)
+
+		// * Notes still too long continue on pages of their own
+		for len(rest) > 0 {
+			full := e.doc.height - 2*pageMargin - footerHeight
+			layout, rest = e.layoutNotes("Notes (continued)", rest, width, full, scale)
+			e.placeNotes(e.doc.addPage(), layout)
+		}
+	}
+}
+
+// layoutNotes flows notes paragraphs under a heading into a region of the
+// given height, returning the paragraphs that did not fit
+func (e *pdfExporter) layoutNotes(heading string, paragraphs []string, width, height, scale float64) (*slideLayout, []string) {
+	newLayout := func() *slideLayout {
+		layout := newSlideLayout(width+2*slidePadding, height, scale, e.palette, styles.Get(e.opts.CodeStyle), nil)
+		layout.y = 0
+		return layout
+	}
+
+	layout := newLayout()
+	layout.paragraph([]textRun{{text: heading, font: fontBold, color: e.palette.Heading}}, 0, baseFontSize*(scale+0.05))
+
+	for i, paragraph := range paragraphs {
+		runs := []textRun{{text: paragraph, font: fontRegular, color: e.palette.Text}}
+
+		// ? Try the paragraph first, keeping at least one so every page
+		// makes progress
+		probe := newLayout()
+		probe.y = layout.y
+		probe.paragraph(runs, 0, baseFontSize*scale)
+		if probe.overflowed() && i > 0 {
+			return layout, paragraphs[i:]
+		}
+
+		layout.paragraph(runs, 0, baseFontSize*scale)
+	}
+
+	return layout, nil
+}
+
+// placeNotes draws laid out notes at the bottom of a page, above the footer
+func (e *pdfExporter) placeNotes(page *pdfCanvas, layout *slideLayout) {
+	page.transform(1, pageMargin-slidePadding, pageMargin+footerHeight)
+	page.buf.Write(layout.canvas.buf.Bytes())
+	page.restore()
+}
+
+func (e *pdfExporter) gridPages(perPage int) {
+	columns := 1
+	if perPage > 3 {
+		columns = 2
+	}
+	rows := (perPage + columns - 1) / columns
+
+	gap := 18.0
+	labelHeight := 14.0
+	cellWidth := (e.doc.width - 2*pageMargin - float64(columns-1)*gap) / float64(columns)
+	cellHeight := (e.doc.height - 2*pageMargin - footerHeight - float64(rows-1)*gap) / float64(rows)
+	width := min(cellWidth, (cellHeight-labelHeight)*slideWidth/slideHeight)
+
+	var page *pdfCanvas
+	for i, slide := range e.presentation.Slides {
+		position := i % perPage
+		if position == 0 {
+			page = e.doc.addPage()
+		}
+
+		column := position % columns
+		row := position / columns
+		x := pageMargin + float64(column)*(cellWidth+gap) + (cellWidth-width)/2
+		top := pageMargin + float64(row)*(cellHeight+gap)
+
+		height := e.drawSlide(page, slide, x, top, width)
+		label := fmt.Sprintf("Slide %d", i+1)
+		page.text(x, e.doc.height-top-height-labelHeight+3, fontRegular, 9, e.palette.Muted, label)
+	}
+}
+
+func (e *pdfExporter) footers() {
+	parts := []string{documentTitle(e.presentation)}
+	if e.presentation.Author != "" {
+		parts = append(parts, e.presentation.Author)
+	}
+	if !e.presentation.Date.IsZero() {
+		parts = append(parts, e.presentation.Date.Format("2006-01-02"))
+	}
+	caption := encodeWinAnsi(strings.Join(parts, " · "))
+
+	for i, page := range e.doc.pages {
+		y := pageMargin / 2
+		page.text(pageMargin, y, fontRegular, 9, e.palette.Muted, caption)
+
+		number := fmt.Sprintf("%d / %d", i+1, len(e.doc.pages))
+		page.text(e.doc.width-pageMargin-textWidth(number, fontRegular, 9), y, fontRegular, 9, e.palette.Muted, number)
+	}
+}
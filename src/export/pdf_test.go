@@ -0,0 +1,192 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/data"
+)
+
+// checkXref follows startxref to the cross-reference table and checks that
+// every entry points at the start of its object
+func checkXref(t *testing.T, pdf []byte) {
+	t.Helper()
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(pdf)
+	if match == nil {
+		t.Fatal("Expected the file to end with startxref and the EOF marker")
+	}
+	start, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(pdf[start:], []byte("xref\n")) {
+		t.Fatalf("Expected xref at offset %d", start)
+	}
+
+	lines := strings.Split(string(pdf[start:]), "\n")
+	var first, count int
+	if _, err := fmt.Sscanf(lines[1], "%d %d", &first, &count); err != nil || first != 0 {
+		t.Fatalf("Expected an xref subsection from object 0, got %q", lines[1])
+	}
+	if lines[2] != "0000000000 65535 f " {
+		t.Errorf("Expected the free head entry, got %q", lines[2])
+	}
+
+	for id := 1; id < count; id++ {
+		entry := lines[2+id]
+		if len(entry) != 19 || !strings.HasSuffix(entry, " 00000 n ") {
+			t.Fatalf("Expected a 20 byte in-use entry for object %d, got %q", id, entry)
+		}
+		offset, _ := strconv.Atoi(entry[:10])
+		if header := fmt.Sprintf("%d 0 obj\n", id); !bytes.HasPrefix(pdf[offset:], []byte(header)) {
+			t.Errorf("Expected object %d at offset %d, got %q", id, offset, pdf[offset:min(offset+12, len(pdf))])
+		}
+	}
+
+	if !bytes.Contains(pdf, fmt.Appendf(nil, "/Size %d ", count)) {
+		t.Errorf("Expected trailer size %d", count)
+	}
+}
+
+func TestPDF(t *testing.T) {
+	presentation, err := data.ParseFromString(testDeck, "test.md")
+	if err != nil {
+		t.Fatalf("Expected deck to parse, got %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		opts  PDFOptions
+		pages int
+	}{
+		{"Slides", PDFOptions{CodeStyle: "monokai"}, 3},
+		{"Notes", PDFOptions{CodeStyle: "monokai", Notes: true}, 3},
+		{"Grid", PDFOptions{CodeStyle: "monokai", PerPage: 2}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := PDF(&out, presentation, tt.opts); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			pdf := out.Bytes()
+
+			if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) {
+				t.Error("Expected a PDF 1.4 header")
+			}
+			checkXref(t, pdf)
+
+			if !bytes.Contains(pdf, fmt.Appendf(nil, "/Count %d >>", tt.pages)) {
+				t.Errorf("Expected %d pages", tt.pages)
+			}
+
+			// * Stream lengths must match what sits between stream and endstream
+			streams := regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)endstream`).FindAllSubmatch(pdf, -1)
+			if len(streams) != tt.pages {
+				t.Errorf("Expected %d content streams, got %d", tt.pages, len(streams))
+			}
+			for _, stream := range streams {
+				if length, _ := strconv.Atoi(string(stream[1])); length != len(stream[2]) {
+					t.Errorf("Expected stream length %d, got %d", len(stream[2]), length)
+				}
+			}
+
+			notes := bytes.Contains(pdf, []byte("roadmap"))
+			if notes != tt.opts.Notes {
+				t.Errorf("Expected notes printed %v, got %v", tt.opts.Notes, notes)
+			}
+		})
+	}
+}
+
+func TestPDFLongNotes(t *testing.T) {
+	notes := func(count int) string {
+		lines := make([]string, count)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("Point %d of the talk.", i+1)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	tests := []struct {
+		name      string
+		lines     int
+		pages     int
+		continued bool
+	}{
+		{"Fits at full size", 5, 1, false},
+		{"Shrinks to fit", 35, 1, false},
+		{"Continues on more pages", 200, 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			presentation, err := data.ParseFromString("# Slide\n\n???\n"+notes(tt.lines), "test.md")
+			if err != nil {
+				t.Fatalf("Expected deck to parse, got %v", err)
+			}
+
+			var out bytes.Buffer
+			if err := PDF(&out, presentation, PDFOptions{CodeStyle: "github", Notes: true}); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			pdf := out.Bytes()
+
+			if !bytes.Contains(pdf, fmt.Appendf(nil, "/Count %d >>", tt.pages)) {
+				t.Errorf("Expected %d pages", tt.pages)
+			}
+			if continued := bytes.Contains(pdf, []byte("continued")); continued != tt.continued {
+				t.Errorf("Expected a continued heading %v, got %v", tt.continued, continued)
+			}
+			// ? Words are placed one at a time
+			if got := bytes.Count(pdf, []byte("(talk.)")); got != tt.lines {
+				t.Errorf("Expected all %d points printed, got %d", tt.lines, got)
+			}
+		})
+	}
+}
+
+func TestPDFNotesNeedOneSlidePerPage(t *testing.T) {
+	presentation, _ := data.ParseFromString("# One", "test.md")
+	if err := PDF(&bytes.Buffer{}, presentation, PDFOptions{Notes: true, PerPage: 4}); err == nil {
+		t.Error("Expected an error for notes with several slides per page")
+	}
+}
+
+func TestEncodeWinAnsi(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"ASCII", "Hello (world)", "Hello (world)"},
+		{"Latin-1", "café ü", "caf\xe9 \xfc"},
+		{"WinAnsi specials", "€ “quoted” – …", "\x80 \x93quoted\x94 \x96 \x85"},
+		{"Arrow fallbacks", "a → b ⇒ c", "a -> b => c"},
+		{"Box drawing fallbacks", "│─━", "|--"},
+		{"Checkmarks", "✓ ✗", "v x"},
+		{"Tabs", "a\tb", "a    b"},
+		{"Emoji dropped", "ship 🚀 it 👍🏽", "ship  it "},
+		{"Joined emoji dropped", "👩‍💻!", "!"},
+		{"Symbol replaced, variation selector dropped", "❤️", "?"},
+		{"Unknown replaced", "日本 λ", "?? ?"},
+		{"Controls dropped", "a\x07b", "ab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeWinAnsi(tt.text); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEscapePDFString(t *testing.T) {
+	if got := escapePDFString(`a (b) \ c`); got != `a \(b\) \\ c` {
+		t.Errorf("Expected escaped parentheses and backslash, got %q", got)
+	}
+}
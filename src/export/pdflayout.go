@@ -0,0 +1,415 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+)
+
+const (
+	// * Slides are laid out on a 16:9 canvas and scaled onto pages
+	slideWidth   = 800.0
+	slideHeight  = 450.0
+	slidePadding = 36.0
+
+	baseFontSize = 18.0
+	minFontScale = 0.45
+)
+
+// * pdfPalette holds the colors used while laying out slides
+type pdfPalette struct {
+	Text      rgb
+	Heading   rgb
+	Secondary rgb
+	Accent    rgb
+	Muted     rgb
+	Border    rgb
+	Page      rgb
+	CodeBack  rgb
+}
+
+// * textRun is a piece of inline text sharing one font and color
+type textRun struct {
+	text  string
+	font  pdfFont
+	color rgb
+}
+
+// * slideLayout flows markdown blocks top to bottom onto a canvas whose
+// * origin is the bottom-left corner, as PDF expects
+type slideLayout struct {
+	canvas  *pdfCanvas
+	palette pdfPalette
+	code    *chroma.Style
+	source  []byte
+
+	width  float64
+	height float64
+	scale  float64
+	y      float64
+}
+
+func newSlideLayout(width, height, scale float64, palette pdfPalette, code *chroma.Style, source []byte) *slideLayout {
+	return &slideLayout{
+		canvas:  &pdfCanvas{},
+		palette: palette,
+		code:    code,
+		source:  source,
+		width:   width,
+		height:  height,
+		scale:   scale,
+		y:       slidePadding,
+	}
+}
+
+func (l *slideLayout) overflowed() bool {
+	return l.y > l.height-slidePadding/2
+}
+
+func (l *slideLayout) contentWidth(indent float64) float64 {
+	return l.width - 2*slidePadding - indent
+}
+
+func (l *slideLayout) bodySize() float64 {
+	return baseFontSize * l.scale
+}
+
+// baseline converts a distance from the top into a PDF y coordinate
+func (l *slideLayout) baseline(top float64) float64 {
+	return l.height - top
+}
+
+func (l *slideLayout) blocks(parent ast.Node, indent float64, color rgb) {
+	for node := parent.FirstChild(); node != nil; node = node.NextSibling() {
+		l.block(node, indent, color)
+	}
+}
+
+func (l *slideLayout) block(node ast.Node, indent float64, color rgb) {
+	size := l.bodySize()
+
+	switch n := node.(type) {
+	case *ast.Heading:
+		scales := [...]float64{2.0, 1.6, 1.3, 1.15, 1.05, 1.0}
+		headingSize := size * scales[min(n.Level, 6)-1]
+		headingColor := l.palette.Heading
+		if n.Level > 3 {
+			headingColor = l.palette.Secondary
+		}
+
+		l.y += headingSize * 0.2
+		l.paragraph(l.runs(n, fontBold, headingColor, nil), indent, headingSize)
+		l.y += headingSize * 0.35
+
+	case *ast.Paragraph, *ast.TextBlock:
+		l.paragraph(l.runs(n, fontRegular, color, nil), indent, size)
+		if _, tight := n.(*ast.TextBlock); !tight {
+			l.y += size * 0.5
+		}
+
+	case *ast.List:
+		l.list(n, indent, color)
+		l.y += size * 0.4
+
+	case *ast.FencedCodeBlock:
+		l.codeBlock(string(n.Language(l.source)), blockText(n, l.source), indent)
+
+	case *ast.CodeBlock:
+		l.codeBlock("", blockText(n, l.source), indent)
+
+	case *ast.Blockquote:
+		start := l.y
+		l.blocks(n, indent+size, l.palette.Muted)
+		x := slidePadding + indent + size*0.3
+		l.canvas.line(x, l.baseline(start), x, l.baseline(l.y-size*0.5), 3*l.scale, l.palette.Border)
+
+	case *ast.ThematicBreak:
+		l.y += size * 0.5
+		l.canvas.line(slidePadding+indent, l.baseline(l.y), l.width-slidePadding, l.baseline(l.y), 1, l.palette.Border)
+		l.y += size * 0.5
+
+	case *east.Table:
+		l.table(n, indent, color)
+
+	case *ast.HTMLBlock:
+		// * Comments and raw HTML have no printable form
+
+	default:
+		l.blocks(n, indent, color)
+	}
+}
+
+func (l *slideLayout) list(list *ast.List, indent float64, color rgb) {
+	size := l.bodySize()
+	number := list.Start
+
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		marker := encodeWinAnsi("•")
+		if list.IsOrdered() {
+			marker = fmt.Sprintf("%d.", number)
+			number++
+		}
+
+		markerWidth := max(textWidth(marker, fontRegular, size)+size*0.5, size*1.2)
+		l.canvas.text(slidePadding+indent, l.baseline(l.y+size), fontRegular, size, l.palette.Accent, marker)
+		l.blocks(item, indent+markerWidth, color)
+	}
+}
+
+// runs flattens inline children into styled text runs
+func (l *slideLayout) runs(parent ast.Node, font pdfFont, color rgb, runs []textRun) []textRun {
+	for node := parent.FirstChild(); node != nil; node = node.NextSibling() {
+		switch n := node.(type) {
+		case *ast.Text:
+			runs = append(runs, textRun{text: string(n.Segment.Value(l.source)), font: font, color: color})
+			if n.HardLineBreak() {
+				runs = append(runs, textRun{text: "\n", font: font, color: color})
+			} else if n.SoftLineBreak() {
+				runs = append(runs, textRun{text: " ", font: font, color: color})
+			}
+
+		case *ast.String:
+			runs = append(runs, textRun{text: string(n.Value), font: font, color: color})
+
+		case *ast.CodeSpan:
+			runs = append(runs, textRun{text: string(n.Text(l.source)), font: fontMono, color: l.palette.Accent})
+
+		case *ast.Emphasis:
+			emphasis := fontItalic
+			if n.Level > 1 || font == fontBold {
+				emphasis = fontBold
+			}
+			runs = l.runs(n, emphasis, color, runs)
+
+		case *ast.Link:
+			runs = l.runs(n, font, l.palette.Accent, runs)
+
+		case *ast.AutoLink:
+			runs = append(runs, textRun{text: string(n.Label(l.source)), font: font, color: l.palette.Accent})
+
+		case *ast.Image:
+			runs = append(runs, textRun{text: "[" + string(n.Text(l.source)) + "]", font: fontItalic, color: l.palette.Muted})
+
+		case *ast.RawHTML:
+			// * Inline HTML is not printable
+
+		default:
+			runs = l.runs(n, font, color, runs)
+		}
+	}
+
+	return runs
+}
+
+// * placedWord is a word positioned on the current line
+type placedWord struct {
+	text  string
+	font  pdfFont
+	color rgb
+	x     float64
+}
+
+// paragraph word-wraps runs into the content width and draws them
+func (l *slideLayout) paragraph(runs []textRun, indent, size float64) {
+	width := l.contentWidth(indent)
+	line := make([]placedWord, 0)
+	x := 0.0
+	pendingSpace := false
+
+	flush := func() {
+		for _, word := range line {
+			l.canvas.text(slidePadding+indent+word.x, l.baseline(l.y+size), word.font, size, word.color, word.text)
+		}
+		line = line[:0]
+		x = 0
+		pendingSpace = false
+		l.y += size * 1.35
+	}
+
+	for _, run := range runs {
+		if run.text == "\n" {
+			flush()
+			continue
+		}
+
+		text := encodeWinAnsi(run.text)
+		for i, word := range strings.Split(text, " ") {
+			if i > 0 {
+				pendingSpace = true
+			}
+			if word == "" {
+				continue
+			}
+
+			space := 0.0
+			if pendingSpace && len(line) > 0 {
+				space = textWidth(" ", run.font, size)
+			}
+
+			wordWidth := textWidth(word, run.font, size)
+			if len(line) > 0 && x+space+wordWidth > width {
+				flush()
+				space = 0
+			}
+
+			line = append(line, placedWord{text: word, font: run.font, color: run.color, x: x + space})
+			x += space + wordWidth
+			pendingSpace = false
+		}
+	}
+
+	if len(line) > 0 {
+		flush()
+	}
+}
+
+func (l *slideLayout) codeBlock(language, code string, indent float64) {
+	size := l.bodySize() * 0.8
+	lineHeight := size * 1.3
+	pad := size * 0.6
+	maxChars := max(int(l.contentWidth(indent)-2*pad)/int(max(textWidth(" ", fontMono, size), 1)), 10)
+
+	lines := l.highlight(language, strings.TrimRight(code, "\n"), maxChars)
+
+	background := l.palette.CodeBack
+	if entry := l.code.Get(chroma.Background); entry.Background.IsSet() {
+		background = colourToRGB(entry.Background)
+	}
+
+	height := float64(len(lines))*lineHeight + 2*pad
+	l.canvas.rect(slidePadding+indent, l.baseline(l.y+height), l.contentWidth(indent), height, background)
+
+	top := l.y + pad
+	for i, runs := range lines {
+		x := slidePadding + indent + pad
+		for _, run := range runs {
+			l.canvas.text(x, l.baseline(top+float64(i)*lineHeight+size), run.font, size, run.color, run.text)
+			x += textWidth(run.text, run.font, size)
+		}
+	}
+
+	l.y += height + l.bodySize()*0.5
+}
+
+// highlight tokenises code with chroma and hard-wraps it into lines of runs
+func (l *slideLayout) highlight(language, code string, maxChars int) [][]textRun {
+	lines := [][]textRun{{}}
+	column := 0
+
+	iterator, err := lexerFor(language, code).Tokenise(nil, code)
+	if err != nil {
+		iterator = chroma.Literator(chroma.Token{Type: chroma.Text, Value: code})
+	}
+
+	for token := iterator(); token != chroma.EOF; token = iterator() {
+		entry := l.code.Get(token.Type)
+
+		color := l.palette.Text
+		if entry.Colour.IsSet() {
+			color = colourToRGB(entry.Colour)
+		}
+		font := fontMono
+		if entry.Bold == chroma.Yes {
+			font = fontMonoBold
+		}
+
+		for i, part := range strings.Split(token.Value, "\n") {
+			if i > 0 {
+				lines = append(lines, []textRun{})
+				column = 0
+			}
+
+			text := encodeWinAnsi(part)
+			for len(text) > 0 {
+				// ? Wrap lines wider than the code box
+				if column >= maxChars {
+					lines = append(lines, []textRun{})
+					column = 0
+				}
+
+				take := min(len(text), maxChars-column)
+				lines[len(lines)-1] = append(lines[len(lines)-1], textRun{text: text[:take], font: font, color: color})
+				column += take
+				text = text[take:]
+			}
+		}
+	}
+
+	return lines
+}
+
+func (l *slideLayout) table(table *east.Table, indent float64, color rgb) {
+	size := l.bodySize() * 0.85
+	rows := make([][][]textRun, 0)
+	header := 0
+
+	for row := table.FirstChild(); row != nil; row = row.NextSibling() {
+		font := fontRegular
+		if _, ok := row.(*east.TableHeader); ok {
+			font = fontBold
+			header++
+		}
+
+		cells := make([][]textRun, 0)
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, l.runs(cell, font, color, nil))
+		}
+		rows = append(rows, cells)
+	}
+
+	// * Size columns to their widest cell, sharing out any excess evenly
+	widths := make([]float64, 0)
+	for _, cells := range rows {
+		for i, runs := range cells {
+			width := size
+			for _, run := range runs {
+				width += textWidth(encodeWinAnsi(run.text), run.font, size)
+			}
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], width+size)
+		}
+	}
+
+	total := 0.0
+	for _, width := range widths {
+		total += width
+	}
+	if available := l.contentWidth(indent); total > available {
+		for i := range widths {
+			widths[i] *= available / total
+		}
+	}
+
+	for r, cells := range rows {
+		x := slidePadding + indent
+		for i, runs := range cells {
+			cellX := x + size*0.5
+			for _, run := range runs {
+				text := encodeWinAnsi(run.text)
+				l.canvas.text(cellX, l.baseline(l.y+size), run.font, size, run.color, text)
+				cellX += textWidth(text, run.font, size)
+			}
+			x += widths[i]
+		}
+
+		l.y += size * 1.5
+		if r < header || r == len(rows)-1 {
+			l.canvas.line(slidePadding+indent, l.baseline(l.y-size*0.2), x, l.baseline(l.y-size*0.2), 0.75, l.palette.Border)
+		}
+	}
+
+	l.y += l.bodySize() * 0.5
+}
+
+func colourToRGB(c chroma.Colour) rgb {
+	return rgb{
+		R: float64(c.Red()) / 255,
+		G: float64(c.Green()) / 255,
+		B: float64(c.Blue()) / 255,
+	}
+}
@@ -0,0 +1,253 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// * pdfFont names one of the standard Type1 fonts every PDF reader ships
+type pdfFont int
+
+const (
+	fontRegular pdfFont = iota
+	fontBold
+	fontItalic
+	fontMono
+	fontMonoBold
+)
+
+var pdfFontNames = [...]string{
+	fontRegular:  "Helvetica",
+	fontBold:     "Helvetica-Bold",
+	fontItalic:   "Helvetica-Oblique",
+	fontMono:     "Courier",
+	fontMonoBold: "Courier-Bold",
+}
+
+// * Helvetica advance widths for ASCII 32-126 in 1/1000 em
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// * WinAnsiEncoding code points outside Latin-1
+var winAnsiSpecials = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// * Common symbols without a WinAnsi glyph
+var winAnsiFallbacks = map[rune]string{
+	'→': "->", '←': "<-", '↑': "^", '↓': "v", '⇒': "=>", '✓': "v", '✔': "v",
+	'✗': "x", '✘': "x", '━': "-", '─': "-", '│': "|", '▶': ">", '►': ">",
+}
+
+// encodeWinAnsi converts text to the single-byte encoding used by the
+// standard fonts, dropping emoji and replacing other glyphs it cannot show
+func encodeWinAnsi(text string) string {
+	var buf strings.Builder
+
+	for _, r := range text {
+		switch {
+		case r >= 32 && r <= 126, r >= 160 && r <= 255:
+			buf.WriteByte(byte(r))
+		case r == '\t':
+			buf.WriteString("    ")
+		case winAnsiSpecials[r] != 0:
+			buf.WriteByte(winAnsiSpecials[r])
+		case winAnsiFallbacks[r] != "":
+			buf.WriteString(winAnsiFallbacks[r])
+		case r >= 0x1F000, r == 0x200D, unicode.Is(unicode.Variation_Selector, r), unicode.IsControl(r):
+			// * Emoji and joiners have no fallback worth printing
+		default:
+			buf.WriteByte('?')
+		}
+	}
+
+	return buf.String()
+}
+
+// textWidth measures WinAnsi-encoded text in points
+func textWidth(text string, font pdfFont, size float64) float64 {
+	if font == fontMono || font == fontMonoBold {
+		return float64(len(text)) * 600 * size / 1000
+	}
+
+	total := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c >= 32 && c <= 126 {
+			total += helveticaWidths[c-32]
+		} else {
+			total += 556
+		}
+	}
+
+	width := float64(total) * size / 1000
+	// ? Bold glyphs run slightly wider than the regular metrics
+	if font == fontBold {
+		width *= 1.06
+	}
+	return width
+}
+
+func escapePDFString(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", `\r`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// * rgb is a color with components in the 0-1 range
+type rgb struct {
+	R, G, B float64
+}
+
+func parseHexColor(hex string) rgb {
+	var r, g, b int
+	if _, err := fmt.Sscanf(strings.TrimPrefix(hex, "#"), "%02x%02x%02x", &r, &g, &b); err != nil {
+		return rgb{}
+	}
+	return rgb{R: float64(r) / 255, G: float64(g) / 255, B: float64(b) / 255}
+}
+
+// * pdfCanvas accumulates the content stream of one page
+type pdfCanvas struct {
+	buf bytes.Buffer
+}
+
+func (c *pdfCanvas) op(format string, args ...any) {
+	fmt.Fprintf(&c.buf, format+"\n", args...)
+}
+
+func (c *pdfCanvas) fillColor(color rgb) {
+	c.op("%.3f %.3f %.3f rg", color.R, color.G, color.B)
+}
+
+func (c *pdfCanvas) strokeColor(color rgb) {
+	c.op("%.3f %.3f %.3f RG", color.R, color.G, color.B)
+}
+
+func (c *pdfCanvas) rect(x, y, w, h float64, color rgb) {
+	c.fillColor(color)
+	c.op("%.2f %.2f %.2f %.2f re f", x, y, w, h)
+}
+
+func (c *pdfCanvas) strokeRect(x, y, w, h, lineWidth float64, color rgb) {
+	c.strokeColor(color)
+	c.op("%.2f w %.2f %.2f %.2f %.2f re S", lineWidth, x, y, w, h)
+}
+
+func (c *pdfCanvas) line(x1, y1, x2, y2, lineWidth float64, color rgb) {
+	c.strokeColor(color)
+	c.op("%.2f w %.2f %.2f m %.2f %.2f l S", lineWidth, x1, y1, x2, y2)
+}
+
+// text draws WinAnsi-encoded text with its baseline at y
+func (c *pdfCanvas) text(x, y float64, font pdfFont, size float64, color rgb, text string) {
+	if text == "" {
+		return
+	}
+	c.fillColor(color)
+	c.op("BT /F%d %.2f Tf %.2f %.2f Td (%s) Tj ET", int(font)+1, size, x, y, escapePDFString(text))
+}
+
+// transform maps a unit-less slide coordinate space onto the page
+func (c *pdfCanvas) transform(scale, x, y float64) {
+	c.op("q %.4f 0 0 %.4f %.2f %.2f cm", scale, scale, x, y)
+}
+
+func (c *pdfCanvas) restore() {
+	c.op("Q")
+}
+
+// * pdfDocument assembles pages into a PDF 1.4 file
+type pdfDocument struct {
+	width  float64
+	height float64
+	pages  []*pdfCanvas
+	info   map[string]string
+}
+
+func newPDFDocument(width, height float64) *pdfDocument {
+	return &pdfDocument{
+		width:  width,
+		height: height,
+		info:   make(map[string]string),
+	}
+}
+
+func (d *pdfDocument) addPage() *pdfCanvas {
+	page := &pdfCanvas{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+func (d *pdfDocument) write(w io.Writer) error {
+	objects := make([]string, 0)
+	add := func(body string) int {
+		objects = append(objects, body)
+		return len(objects)
+	}
+
+	// * Fixed objects: catalog, page tree, fonts and document info
+	catalog := add("")
+	pageTree := add("")
+
+	fonts := make([]string, 0, len(pdfFontNames))
+	for i, name := range pdfFontNames {
+		id := add(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+		fonts = append(fonts, fmt.Sprintf("/F%d %d 0 R", i+1, id))
+	}
+	resources := fmt.Sprintf("<< /Font << %s >> >>", strings.Join(fonts, " "))
+
+	infoEntries := []string{fmt.Sprintf("/Producer (%s)", escapePDFString("slate"))}
+	for _, key := range []string{"Title", "Author", "Subject"} {
+		if value := d.info[key]; value != "" {
+			infoEntries = append(infoEntries, fmt.Sprintf("/%s (%s)", key, escapePDFString(encodeWinAnsi(value))))
+		}
+	}
+	infoEntries = append(infoEntries, fmt.Sprintf("/CreationDate (D:%s)", time.Now().UTC().Format("20060102150405Z")))
+	info := add(fmt.Sprintf("<< %s >>", strings.Join(infoEntries, " ")))
+
+	kids := make([]string, 0, len(d.pages))
+	for _, page := range d.pages {
+		stream := page.buf.String()
+		content := add(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(stream), stream))
+		id := add(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] /Resources %s /Contents %d 0 R >>",
+			pageTree, d.width, d.height, resources, content))
+		kids = append(kids, fmt.Sprintf("%d 0 R", id))
+	}
+
+	objects[catalog-1] = fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pageTree)
+	objects[pageTree-1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))
+
+	// * Serialize with a cross-reference table of byte offsets
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	offsets := make([]int, len(objects))
+	for i, body := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, body)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objects)+1, catalog, info, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
@@ -0,0 +1,257 @@
+package figlet
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// * Fonts bundled with slate, one FIGlet font file each
+//
+//go:embed fonts/*.flf
+var fontFiles embed.FS
+
+// * Bundled fonts already parsed, by name
+var (
+	loaded   = make(map[string]*Font)
+	loadedMu sync.Mutex
+)
+
+// * FIGlet fonts hold ASCII 32 to 126 and then these German characters
+var germanChars = []rune{'Ä', 'Ö', 'Ü', 'ä', 'ö', 'ü', 'ß'}
+
+// * Layout bits of the full_layout header field
+const (
+	layoutKerning  = 64
+	layoutSmushing = 128
+)
+
+// * Font is a FIGlet font. Every character in a glyph is taken to take up
+// one column on screen.
+type Font struct {
+	Name   string
+	Height int
+	// hardblank stands for a space that is never kerned away
+	hardblank rune
+	// kerning moves glyphs together until they touch, otherwise every
+	// glyph keeps its full width
+	kerning bool
+	glyphs  map[rune][]string
+}
+
+// Load reads one of the bundled fonts by name. Fonts are parsed once and
+// shared, as nothing changes them after parsing.
+func Load(name string) (*Font, error) {
+	loadedMu.Lock()
+	defer loadedMu.Unlock()
+
+	if font, ok := loaded[name]; ok {
+		return font, nil
+	}
+
+	file, err := fontFiles.Open("fonts/" + name + ".flf")
+	if err != nil {
+		return nil, fmt.Errorf("unknown font %q", name)
+	}
+	defer func() { _ = file.Close() }()
+
+	font, err := Parse(name, file)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded[name] = font
+	return font, nil
+}
+
+// Parse reads a font in the FIGlet .flf format. Fonts that ask for
+// smushing are kerned instead, which keeps their glyphs whole.
+func Parse(name string, r io.Reader) (*Font, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("failed to read font %s: missing header", name)
+	}
+
+	// * flf2a$ height baseline max_length old_layout comment_lines [print_direction full_layout codetag_count]
+	header := strings.Fields(scanner.Text())
+	if len(header) < 6 || !strings.HasPrefix(header[0], "flf2a") || len(header[0]) == len("flf2a") {
+		return nil, fmt.Errorf("failed to read font %s: not a FIGlet font", name)
+	}
+
+	numbers := make([]int, len(header)-1)
+	for i, field := range header[1:] {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read font %s: invalid header field %q", name, field)
+		}
+		numbers[i] = n
+	}
+	if numbers[0] < 1 {
+		return nil, fmt.Errorf("failed to read font %s: invalid height %d", name, numbers[0])
+	}
+
+	font := &Font{
+		Name:      name,
+		Height:    numbers[0],
+		hardblank: []rune(header[0])[len("flf2a")],
+		kerning:   numbers[3] >= 0,
+		glyphs:    make(map[rune][]string),
+	}
+	// ? The full layout, when given, replaces the old one
+	if len(numbers) > 6 {
+		font.kerning = numbers[6]&(layoutKerning|layoutSmushing) != 0
+	}
+
+	for i := 0; i < numbers[4]; i++ {
+		scanner.Scan()
+	}
+
+	chars := make([]rune, 0, 127-32+len(germanChars))
+	for c := rune(32); c < 127; c++ {
+		chars = append(chars, c)
+	}
+	chars = append(chars, germanChars...)
+
+	for _, c := range chars {
+		glyph, err := font.readGlyph(scanner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read font %s: %w", name, err)
+		}
+		font.add(c, glyph)
+	}
+
+	// * Code tagged characters follow, each after a line with its code
+	for scanner.Scan() {
+		tag := strings.Fields(scanner.Text())
+		if len(tag) == 0 {
+			continue
+		}
+		code, err := strconv.ParseInt(tag[0], 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read font %s: invalid character code %q", name, tag[0])
+		}
+		glyph, err := font.readGlyph(scanner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read font %s: %w", name, err)
+		}
+		font.add(rune(code), glyph)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read font %s: %w", name, err)
+	}
+
+	return font, nil
+}
+
+// readGlyph reads the lines of one glyph, removing the end marks that
+// close each line
+func (f *Font) readGlyph(scanner *bufio.Scanner) ([]string, error) {
+	glyph := make([]string, f.Height)
+	for i := range glyph {
+		if !scanner.Scan() {
+			return nil, errors.New("unexpected end of font")
+		}
+
+		line := strings.TrimRightFunc(scanner.Text(), unicode.IsSpace)
+		if line != "" {
+			mark := line[len(line)-1:]
+			line = strings.TrimRight(line, mark)
+		}
+		glyph[i] = line
+	}
+
+	return glyph, nil
+}
+
+// add keeps a glyph, leaving out empty ones so their characters count as
+// missing
+func (f *Font) add(c rune, glyph []string) {
+	for _, line := range glyph {
+		if line != "" {
+			f.glyphs[c] = glyph
+			return
+		}
+	}
+}
+
+// Render lays text out in the font, one string per line. It returns false
+// when the font has no glyph for one of the characters.
+func (f *Font) Render(text string) ([]string, bool) {
+	rows := make([][]rune, f.Height)
+	width := 0
+
+	for _, c := range text {
+		glyph, ok := f.glyphs[c]
+		if !ok {
+			return nil, false
+		}
+
+		// ? Rows are kept the same width so glyphs stay lined up
+		lines := make([][]rune, len(glyph))
+		for i, line := range glyph {
+			lines[i] = []rune(line)
+			rows[i] = append(rows[i], []rune(strings.Repeat(" ", width-len(rows[i])))...)
+		}
+
+		overlap := 0
+		if f.kerning {
+			overlap = kerningOverlap(rows, lines, width)
+		}
+
+		for i, line := range lines {
+			// * Blanks ending the row go first, then those starting the glyph
+			cut := min(overlap, trailingBlanks(rows[i]))
+			rows[i] = append(rows[i][:len(rows[i])-cut], line[overlap-cut:]...)
+			width = max(width, len(rows[i]))
+		}
+	}
+
+	result := make([]string, f.Height)
+	for i, row := range rows {
+		result[i] = strings.TrimRight(strings.ReplaceAll(string(row), string(f.hardblank), " "), " ")
+	}
+
+	return result, true
+}
+
+// kerningOverlap is how far a glyph can move left into rows width columns
+// wide before it touches what is already there
+func kerningOverlap(rows, glyph [][]rune, width int) int {
+	overlap := width
+	for i, line := range glyph {
+		overlap = min(overlap, len(line), trailingBlanks(rows[i])+leadingBlanks(line))
+	}
+	return overlap
+}
+
+func leadingBlanks(runes []rune) int {
+	n := 0
+	for n < len(runes) && runes[n] == ' ' {
+		n++
+	}
+	return n
+}
+
+func trailingBlanks(runes []rune) int {
+	n := 0
+	for n < len(runes) && runes[len(runes)-1-n] == ' ' {
+		n++
+	}
+	return n
+}
+
+// Width returns how many columns rendered lines take up
+func Width(lines []string) int {
+	width := 0
+	for _, line := range lines {
+		width = max(width, len([]rune(line)))
+	}
+	return width
+}
@@ -0,0 +1,118 @@
+package figlet
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testFont builds a two line font where every character is blank except
+// the ones given
+func testFont(layout string, glyphs map[rune][2]string) string {
+	var b strings.Builder
+	b.WriteString("flf2a$ 2 2 8 " + layout + " 1\nA test font\n")
+	for c := rune(32); c < 127; c++ {
+		glyph, ok := glyphs[c]
+		if !ok {
+			glyph = [2]string{"", ""}
+		}
+		b.WriteString(glyph[0] + "@\n" + glyph[1] + "@@\n")
+	}
+	for range germanChars {
+		b.WriteString("@\n@@\n")
+	}
+	return b.String()
+}
+
+func TestRender(t *testing.T) {
+	glyphs := map[rune][2]string{
+		' ': {"$$", "$$"},
+		'L': {"#  ", "## "},
+		'T': {"###", " # "},
+		'/': {"  #", " # "},
+	}
+
+	tests := []struct {
+		name     string
+		layout   string
+		text     string
+		expected []string
+	}{
+		{"Full width", "-1", "LT", []string{"#  ###", "##  #"}},
+		{"Kerning", "0", "LT", []string{"####", "###"}},
+		{"Kerning stops at the closest rows", "0", "L/", []string{"#  #", "###"}},
+		{"Hardblanks are not kerned away", "0", "L L", []string{"#   #", "##  ##"}},
+		{"Smushing is kerned", "1", "LT", []string{"####", "###"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font, err := Parse("test", strings.NewReader(testFont(tt.layout, glyphs)))
+			if err != nil {
+				t.Fatalf("Expected font to parse, got %v", err)
+			}
+
+			lines, ok := font.Render(tt.text)
+			if !ok {
+				t.Fatal("Expected every character to have a glyph")
+			}
+			if !slices.Equal(lines, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, lines)
+			}
+		})
+	}
+}
+
+func TestRenderMissingGlyph(t *testing.T) {
+	font, err := Parse("test", strings.NewReader(testFont("-1", map[rune][2]string{'A': {"A", "A"}})))
+	if err != nil {
+		t.Fatalf("Expected font to parse, got %v", err)
+	}
+
+	for _, text := range []string{"AB", "Aé"} {
+		if _, ok := font.Render(text); ok {
+			t.Errorf("Expected %q to have a missing glyph", text)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"Empty", ""},
+		{"Not a font", "hello world\n"},
+		{"Bad height", "flf2a$ x 2 8 -1 0\n"},
+		{"Too few glyphs", "flf2a$ 1 1 8 -1 0\nA@@\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse("test", strings.NewReader(tt.source)); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestBundledFonts(t *testing.T) {
+	for _, name := range []string{"block", "small"} {
+		font, err := Load(name)
+		if err != nil {
+			t.Fatalf("Expected font %s to load, got %v", name, err)
+		}
+
+		// * Every printable ASCII character has a glyph
+		for c := rune(33); c < 127; c++ {
+			lines, ok := font.Render(string(c))
+			if !ok || len(lines) != font.Height || Width(lines) == 0 {
+				t.Errorf("Expected font %s to draw %q", name, c)
+			}
+		}
+	}
+
+	if _, err := Load("missing"); err == nil {
+		t.Error("Expected an unknown font to fail")
+	}
+}
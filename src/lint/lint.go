@@ -0,0 +1,251 @@
+package lint
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/terminal"
+	"github.com/Kosha-Nirman/slate/src/theme"
+)
+
+// terminalSupported reports whether terminal slides can run where the deck
+// is checked
+var terminalSupported = terminal.Supported
+
+// * Severity of a reported issue. Errors make `slate lint` fail.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// * Rule names, shown with each issue so they can be looked up or grepped
+const (
+	RuleFrontMatter         = "front-matter"
+	RuleUnknownMetadata     = "unknown-metadata"
+	RuleEmptySlide          = "empty-slide"
+	RuleUnclosedFence       = "unclosed-fence"
+	RuleUnclosedComment     = "unclosed-comment"
+	RuleBrokenLink          = "broken-link"
+	RuleBrokenImage         = "broken-image"
+	RuleOverflowWidth       = "overflow-width"
+	RuleOverflowHeight      = "overflow-height"
+	RuleDuplicateTitle      = "duplicate-title"
+	RuleInvalidColor        = "invalid-color"
+	RuleLowContrast         = "low-contrast"
+	RuleUnknownTransition   = "unknown-transition"
+	RuleInvalidDuration     = "invalid-duration"
+	RuleInvalidColumns      = "invalid-columns"
+	RuleInvalidPlacement    = "invalid-placement"
+	RuleInvalidBigText      = "invalid-bigtext"
+	RuleUnsupportedTerminal = "unsupported-terminal"
+)
+
+// * Issue is one problem found in a deck
+type Issue struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s [%s]", i.File, i.Line, i.Column, i.Severity, i.Message, i.Rule)
+}
+
+// * Options configure the checks that depend on the screen size
+type Options struct {
+	// MaxWidth is the widest a rendered slide line may be
+	MaxWidth int
+	// MaxHeight is the most rendered lines a slide may have
+	MaxHeight int
+	// Config renders slides the way `slate present` would
+	Config *models.Config
+}
+
+// * linter collects issues while walking one deck
+type linter struct {
+	file   string
+	dir    string
+	opts   Options
+	issues []Issue
+	// offset is the number of front matter lines before the slides
+	offset int
+}
+
+func (l *linter) report(line, column int, severity Severity, rule, format string, args ...any) {
+	l.issues = append(l.issues, Issue{
+		File:     l.file,
+		Line:     line,
+		Column:   column,
+		Severity: severity,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// File lints the deck at path
+func File(path string, opts Options) ([]Issue, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return Source(path, string(content), opts)
+}
+
+// Source lints deck content read from path. Relative links resolve against
+// the directory of path.
+func Source(path, content string, opts Options) ([]Issue, error) {
+	l := &linter{
+		file:   path,
+		dir:    filepath.Dir(path),
+		opts:   opts,
+		issues: make([]Issue, 0),
+	}
+
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	body := l.checkFrontMatter(content)
+	tokens := data.Tokenize(body)
+
+	l.checkTokens(tokens)
+	l.checkEmptySlides(tokens)
+
+	// * Parse without the front matter so slides line up with the sections
+	presentation, err := data.ParseFromString(body, path)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.checkSlides(data.Sections(body), presentation); err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(l.issues, func(a, b Issue) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+
+	return l.issues, nil
+}
+
+// HasErrors reports whether any issue is an error
+func HasErrors(issues []Issue) bool {
+	return slices.ContainsFunc(issues, func(issue Issue) bool {
+		return issue.Severity == SeverityError
+	})
+}
+
+// checkSlides runs the checks that need whole slides
+func (l *linter) checkSlides(sections []data.Section, presentation *models.Presentation) error {
+	renderer, err := l.renderer()
+	if err != nil {
+		return err
+	}
+
+	titles := make(map[string]int)
+
+	for i, section := range sections {
+		if i >= presentation.SlideCount() {
+			break
+		}
+		slide := presentation.Slides[i]
+		line := section.Line + l.offset
+
+		// ? Duplicate titles make title jumps and search ambiguous
+		if title := slide.Title(); title != "" {
+			key := strings.ToLower(title)
+			if first, ok := titles[key]; ok {
+				l.report(line+headingLine(section.Content), 1, SeverityWarning, RuleDuplicateTitle,
+					"duplicate slide title %q, also used by slide %d", title, first+1)
+			} else {
+				titles[key] = i
+			}
+		}
+
+		l.checkColors(renderer, slide, section, line)
+		l.checkTransition(slide.Metadata.Transition, line+metadataLine(section.Content, "transition"))
+		l.checkDuration(slide.Metadata.Time, line+metadataLine(section.Content, "time"))
+		l.checkColumns(section.Content, line)
+		l.checkPlacement("align", slide.Metadata.Align, display.Aligns, line+metadataLine(section.Content, "align"))
+		l.checkPlacement("justify", slide.Metadata.Justify, display.Justifies, line+metadataLine(section.Content, "justify"))
+
+		// ? Terminal slides need a pseudo-terminal, which slate only opens on Linux
+		if slide.Metadata.Terminal && !terminalSupported {
+			l.report(line+metadataLine(section.Content, "terminal"), 1, SeverityWarning, RuleUnsupportedTerminal,
+				"terminal slides are only supported on Linux, this slide will show no terminal")
+		}
+
+		// ? Slides without @bigtext take the deck's setting, checked in the front matter
+		if slide.Metadata.BigText != presentation.BigHeadings {
+			l.checkBigText("@bigtext", slide.Metadata.BigText, line+metadataLine(section.Content, "bigtext"))
+		}
+
+		if renderer != nil {
+			if err := l.checkOverflow(renderer, slide, i, line); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (l *linter) renderer() (*display.Renderer, error) {
+	if l.opts.Config == nil {
+		return nil, nil
+	}
+
+	// * Wrap text at the width being checked, as a terminal that size would
+	cfg := *l.opts.Config
+	if l.opts.MaxWidth > 0 {
+		cfg.Presentation.WordWrap = l.opts.MaxWidth
+	}
+
+	// ? Chrome colors do not change how much room a slide takes
+	r, err := display.New(&cfg, theme.ColorScheme{}, l.opts.MaxWidth, l.opts.MaxHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// * Images take up lines too, so find them as the deck would
+	r.SetBaseDir(l.dir)
+
+	return r, nil
+}
+
+// headingLine returns the offset of the first heading within a slide
+func headingLine(content string) int {
+	for _, token := range data.Tokenize(content) {
+		if token.Kind != data.TokenText {
+			continue
+		}
+		for i, line := range token.Lines {
+			if strings.HasPrefix(strings.TrimSpace(line), "#") {
+				return token.Line - 1 + i
+			}
+		}
+	}
+	return 0
+}
+
+// metadataLine returns the offset of the first metadata comment with one of
+// the given keys within a slide
+func metadataLine(content string, keys ...string) int {
+	for i, line := range strings.Split(content, "\n") {
+		for _, match := range metadataKeyRegex.FindAllStringSubmatch(line, -1) {
+			if slices.Contains(keys, strings.ToLower(match[1])) {
+				return i
+			}
+		}
+	}
+	return 0
+}
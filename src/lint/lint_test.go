@@ -0,0 +1,279 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+func rules(issues []Issue) map[string]Issue {
+	found := make(map[string]Issue)
+	for _, issue := range issues {
+		found[issue.Rule] = issue
+	}
+	return found
+}
+
+func TestSourceReportsPositions(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "logo.png"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	content := "---\ntitle: [oops\n---\n\n# One\n\n![logo](logo.png) [guide](missing.md) `[code](nope.md)`\n\n<!-- @colour: red -->\n\n---\n\n---\n\n# One\n\n```go\nfmt.Println()\n"
+	issues, err := Source(filepath.Join(dir, "deck.md"), content, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		rule     string
+		line     int
+		column   int
+		severity Severity
+	}{
+		{RuleFrontMatter, 2, 1, SeverityError},
+		{RuleBrokenLink, 7, 19, SeverityError},
+		{RuleUnknownMetadata, 9, 6, SeverityWarning},
+		{RuleEmptySlide, 11, 1, SeverityWarning},
+		{RuleDuplicateTitle, 15, 1, SeverityWarning},
+		{RuleUnclosedFence, 17, 1, SeverityError},
+	}
+
+	found := rules(issues)
+	for _, tt := range tests {
+		issue, ok := found[tt.rule]
+		if !ok {
+			t.Errorf("Expected a %s issue, got %v", tt.rule, issues)
+			continue
+		}
+		if issue.Line != tt.line || issue.Column != tt.column || issue.Severity != tt.severity {
+			t.Errorf("Expected %s at %d:%d (%s), got %d:%d (%s)",
+				tt.rule, tt.line, tt.column, tt.severity, issue.Line, issue.Column, issue.Severity)
+		}
+	}
+
+	if _, ok := found[RuleBrokenImage]; ok {
+		t.Error("Expected existing image to pass")
+	}
+	if len(issues) != len(tests) {
+		t.Errorf("Expected %d issues, got %d: %v", len(tests), len(issues), issues)
+	}
+}
+
+func TestSourceCleanDeck(t *testing.T) {
+	content := "---\ntitle: Clean\n---\n\n# One\n\n<!-- @notes: hi -->\n\n---\n\n# Two\n\n<!-- @pause -->\n\n[site](https://example.com)\n"
+	issues, err := Source("deck.md", content, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+	if HasErrors(issues) {
+		t.Error("Expected no errors")
+	}
+}
+
+func TestSourceChecksColors(t *testing.T) {
+	content := "# Readable\n\n<!-- @background: navy -->\n<!-- @foreground: #ffaa00 -->\n\n---\n\n# Unreadable\n\n<!-- @background: navy -->\n<!-- @foreground: blue -->\n\n---\n\n# Broken\n\n<!-- @accent: nope -->\n"
+	issues, err := Source("deck.md", content, Options{Config: models.NewDefaultConfig()})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		rule     string
+		line     int
+		severity Severity
+	}{
+		{RuleLowContrast, 10, SeverityWarning},
+		{RuleInvalidColor, 17, SeverityError},
+	}
+
+	found := rules(issues)
+	for _, tt := range tests {
+		issue, ok := found[tt.rule]
+		if !ok {
+			t.Errorf("Expected a %s issue, got %v", tt.rule, issues)
+			continue
+		}
+		if issue.Line != tt.line || issue.Severity != tt.severity {
+			t.Errorf("Expected %s on line %d (%s), got line %d (%s)",
+				tt.rule, tt.line, tt.severity, issue.Line, issue.Severity)
+		}
+	}
+
+	if len(issues) != len(tests) {
+		t.Errorf("Expected %d issues, got %d: %v", len(tests), len(issues), issues)
+	}
+}
+
+func TestSourceChecksTransitions(t *testing.T) {
+	content := "---\ntitle: Deck\ntransition: spin\n---\n\n# One\n\n<!-- @transition: fade -->\n\n---\n\n# Two\n\n<!-- @transition: zoom -->\n"
+	issues, err := Source("deck.md", content, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %v", len(issues), issues)
+	}
+	for i, line := range []int{3, 14} {
+		if issues[i].Rule != RuleUnknownTransition || issues[i].Line != line {
+			t.Errorf("Expected %s on line %d, got %v", RuleUnknownTransition, line, issues[i])
+		}
+	}
+}
+
+func TestSourceChecksDurations(t *testing.T) {
+	content := "---\ntitle: Deck\nduration: twenty minutes\n---\n\n# One\n\n<!-- @time: 90s -->\n\n---\n\n# Two\n\n<!-- @time: 90 -->\n"
+	issues, err := Source("deck.md", content, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %v", len(issues), issues)
+	}
+	for i, line := range []int{3, 14} {
+		if issues[i].Rule != RuleInvalidDuration || issues[i].Line != line {
+			t.Errorf("Expected %s on line %d, got %v", RuleInvalidDuration, line, issues[i])
+		}
+	}
+}
+
+func TestSourceChecksColumns(t *testing.T) {
+	content := "# One\n\n::: columns\n::: column 60%\nLeft\n:::\n::: column 50%\nRight\n:::\n:::\n\n---\n\n# Two\n\n::: columns\n::: column\nOpen\n"
+	issues, err := Source("deck.md", content, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %v", len(issues), issues)
+	}
+	for i, want := range []struct {
+		line     int
+		severity Severity
+	}{{3, SeverityWarning}, {16, SeverityError}} {
+		if issues[i].Rule != RuleInvalidColumns || issues[i].Line != want.line || issues[i].Severity != want.severity {
+			t.Errorf("Expected %s %s on line %d, got %v", want.severity, RuleInvalidColumns, want.line, issues[i])
+		}
+	}
+}
+
+func TestSourceChecksPlacement(t *testing.T) {
+	content := "---\ntitle: Deck\njustify: middle\n---\n\n# One\n\n<!-- @align: center -->\n\n---\n\n# Two\n\n<!-- @align: centre -->\n"
+	issues, err := Source("deck.md", content, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %v", len(issues), issues)
+	}
+	for i, line := range []int{3, 14} {
+		if issues[i].Rule != RuleInvalidPlacement || issues[i].Line != line {
+			t.Errorf("Expected %s on line %d, got %v", RuleInvalidPlacement, line, issues[i])
+		}
+	}
+}
+
+func TestSourceChecksBigText(t *testing.T) {
+	content := "---\ntitle: Deck\nbigHeadings: title\n---\n\n# One\n\n---\n\n<!-- @bigtext -->\n# Two\n\n---\n\n<!-- @bigtext: h7 -->\n# Three\n"
+	issues, err := Source("deck.md", content, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %v", len(issues), issues)
+	}
+	for i, line := range []int{3, 15} {
+		if issues[i].Rule != RuleInvalidBigText || issues[i].Line != line {
+			t.Errorf("Expected %s on line %d, got %v", RuleInvalidBigText, line, issues[i])
+		}
+	}
+}
+
+func TestSourceChecksGlamourStyle(t *testing.T) {
+	content := "---\ntitle: Deck\nglamourStyle: projecter\n---\n\n# One\n"
+	issues, err := Source("deck.md", content, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Rule != RuleFrontMatter || issues[0].Line != 3 {
+		t.Fatalf("Expected %s on line 3, got %v", RuleFrontMatter, issues)
+	}
+	if !strings.Contains(issues[0].Message, "unknown glamour style") {
+		t.Errorf("Expected the message to name the unknown style, got %q", issues[0].Message)
+	}
+}
+
+func TestSourceChecksChromeColors(t *testing.T) {
+	content := "---\ntitle: Deck\npalette: oceans\ncolors:\n  primary: \"#12345\"\n  progress: red\n  muted: 244\n---\n\n# One\n"
+	issues, err := Source("deck.md", content, Options{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []struct {
+		rule string
+		line int
+	}{
+		{RuleFrontMatter, 3},
+		{RuleFrontMatter, 6},
+		{RuleInvalidColor, 5},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %v", len(expected), issues)
+	}
+	for _, want := range expected {
+		found := false
+		for _, issue := range issues {
+			if issue.Rule == want.rule && issue.Line == want.line {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %s on line %d, got %v", want.rule, want.line, issues)
+		}
+	}
+}
+
+func TestSourceChecksTerminalSupport(t *testing.T) {
+	content := "# One\n\n---\n\n# Shell\n\n<!-- @terminal: bash -->\n"
+
+	tests := []struct {
+		name      string
+		supported bool
+		issues    int
+	}{
+		{"Supported", true, 0},
+		{"Unsupported", false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(supported bool) { terminalSupported = supported }(terminalSupported)
+			terminalSupported = tt.supported
+
+			issues, err := Source("deck.md", content, Options{})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(issues) != tt.issues {
+				t.Fatalf("Expected %d issues, got %d: %v", tt.issues, len(issues), issues)
+			}
+			if tt.issues > 0 && (issues[0].Rule != RuleUnsupportedTerminal || issues[0].Line != 7 || issues[0].Severity != SeverityWarning) {
+				t.Errorf("Expected a %s warning on line 7, got %v", RuleUnsupportedTerminal, issues[0])
+			}
+		})
+	}
+}
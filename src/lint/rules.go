@@ -0,0 +1,385 @@
+package lint
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/data"
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/Kosha-Nirman/slate/src/theme"
+	"github.com/Kosha-Nirman/slate/src/timer"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	// Match the line number in a YAML error message
+	yamlLineRegex = regexp.MustCompile(`line (\d+):\s*`)
+	// Match the key of a metadata comment or directive
+	metadataKeyRegex = regexp.MustCompile(`<!--\s*@(\w+)`)
+	// Match inline links and images, capturing the marker and target
+	linkRegex = regexp.MustCompile(`(!?)\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+[^)]*)?\)`)
+	// Match inline code spans, which never contain links
+	codeSpanRegex = regexp.MustCompile("`+[^`]*`+")
+)
+
+// checkFrontMatter reports YAML errors and returns the content after the
+// front matter
+func (l *linter) checkFrontMatter(content string) string {
+	source, body, lines, ok := data.SplitFrontMatter(content)
+	if !ok {
+		return content
+	}
+	l.offset = lines
+
+	metadata, err := data.DecodeFrontMatter(source)
+	if err != nil {
+		// ? YAML counts lines from the first line after the opening ---
+		line := 1
+		if match := yamlLineRegex.FindStringSubmatch(err.Error()); match != nil {
+			if n, convErr := strconv.Atoi(match[1]); convErr == nil {
+				line = n + 1
+			}
+		}
+
+		message := yamlLineRegex.ReplaceAllString(strings.TrimPrefix(err.Error(), "yaml: "), "")
+		l.report(line, 1, SeverityError, RuleFrontMatter, "invalid front matter: %s", message)
+	}
+
+	if transition, ok := metadata["transition"]; ok {
+		l.checkTransition(transition, 1+frontMatterLine(source, "transition"))
+	}
+
+	if duration, ok := metadata["duration"]; ok {
+		l.checkDuration(duration, 1+frontMatterLine(source, "duration"))
+	}
+
+	l.checkPlacement("align", strings.ToLower(metadata["align"]), display.Aligns, 1+frontMatterLine(source, "align"))
+	l.checkPlacement("justify", strings.ToLower(metadata["justify"]), display.Justifies, 1+frontMatterLine(source, "justify"))
+	l.checkBigText("bigHeadings", strings.ToLower(metadata["bigheadings"]), 1+frontMatterLine(source, "bigHeadings"))
+
+	// ? Broken header and footer templates stop the deck from loading
+	deck := models.NewPresentation(l.file)
+	deck.SetMetadata(metadata)
+	for _, layout := range []struct {
+		name   string
+		layout models.LayoutConfig
+	}{
+		{"header", models.LayoutConfig{Header: deck.Header}},
+		{"footer", models.LayoutConfig{Footer: deck.Footer}},
+	} {
+		if err := display.ValidateLayout(layout.layout); err != nil {
+			l.report(1+slotLine(source, layout.name), 1, SeverityError, RuleFrontMatter, "%s", err)
+		}
+	}
+
+	// ? A style that cannot be loaded stops the deck from loading
+	if style, ok := metadata["glamourstyle"]; ok {
+		if err := theme.ValidateStyle(theme.ResolveStylePath(style, l.dir)); err != nil {
+			l.report(1+frontMatterLine(source, "glamourStyle"), 1, SeverityError, RuleFrontMatter, "%s", err)
+		}
+	}
+
+	l.checkChromeColors(source, metadata, deck)
+
+	// ? Cover templates are read when the deck loads, so a missing one stops it
+	for _, key := range []string{"titleTemplate", "closingTemplate"} {
+		target, ok := metadata[strings.ToLower(key)]
+		if !ok {
+			continue
+		}
+		if path, local := l.localPath(target); local {
+			if _, err := os.Stat(path); err != nil {
+				l.report(1+frontMatterLine(source, key), 1, SeverityError, RuleFrontMatter,
+					"%s not found: %s", key, target)
+			}
+		}
+	}
+
+	return body
+}
+
+// checkChromeColors reports a palette or chrome color in the front matter
+// that stops the deck from loading
+func (l *linter) checkChromeColors(source string, metadata map[string]string, deck *models.Presentation) {
+	var palettes map[string]models.ColorsConfig
+	if l.opts.Config != nil {
+		palettes = l.opts.Config.Theme.Palettes
+	}
+	if names := theme.PaletteNames(palettes); deck.Palette != "" && !slices.Contains(names, deck.Palette) {
+		l.report(1+frontMatterLine(source, "palette"), 1, SeverityError, RuleFrontMatter,
+			"unknown palette %q, use one of %s", deck.Palette, strings.Join(names, ", "))
+	}
+
+	// * Keys under colors: that name no color of the chrome
+	var unknown []string
+	for key := range metadata {
+		name, ok := strings.CutPrefix(key, "colors.")
+		if ok && !slices.ContainsFunc(models.ColorNames, func(color string) bool { return strings.EqualFold(color, name) }) {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	for _, name := range unknown {
+		l.report(1+frontMatterLine(source, name), 1, SeverityError, RuleFrontMatter,
+			"unknown color %q, use one of %s", name, strings.Join(models.ColorNames, ", "))
+	}
+
+	for i, value := range deck.Colors.Values() {
+		if value == "" {
+			continue
+		}
+		if _, err := theme.ParseColor(value); err != nil {
+			name := models.ColorNames[i]
+			l.report(1+frontMatterLine(source, name), 1, SeverityError, RuleInvalidColor, "invalid %s color: %s", name, err)
+		}
+	}
+}
+
+func (l *linter) checkTokens(tokens []data.Token) {
+	for _, token := range tokens {
+		line := token.Line + l.offset
+
+		switch token.Kind {
+		case data.TokenFence:
+			if !token.Closed {
+				column := strings.Index(token.Lines[0], strings.TrimSpace(token.Lines[0])) + 1
+				l.report(line, column, SeverityError, RuleUnclosedFence, "code fence is never closed")
+			}
+
+		case data.TokenComment:
+			if !token.Closed {
+				l.report(line, strings.Index(token.Lines[0], "<!--")+1, SeverityError, RuleUnclosedComment,
+					"HTML comment is never closed")
+			}
+			l.checkMetadataKeys(token, line)
+
+		case data.TokenText:
+			l.checkMetadataKeys(token, line)
+			l.checkLinks(token, line)
+		}
+	}
+}
+
+func (l *linter) checkMetadataKeys(token data.Token, line int) {
+	for i, text := range token.Lines {
+		for _, match := range metadataKeyRegex.FindAllStringSubmatchIndex(text, -1) {
+			key := text[match[2]:match[3]]
+			if !data.IsMetadataKey(key) {
+				l.report(line+i, match[2], SeverityWarning, RuleUnknownMetadata,
+					"unknown metadata key @%s", key)
+			}
+		}
+	}
+}
+
+func (l *linter) checkLinks(token data.Token, line int) {
+	for i, text := range token.Lines {
+		// * Blank out code spans so their contents are not read as links
+		text = codeSpanRegex.ReplaceAllStringFunc(text, func(span string) string {
+			return strings.Repeat(" ", len(span))
+		})
+
+		for _, match := range linkRegex.FindAllStringSubmatchIndex(text, -1) {
+			image := match[3] > match[2]
+			target := text[match[4]:match[5]]
+
+			path, ok := l.localPath(target)
+			if !ok {
+				continue
+			}
+			if _, err := os.Stat(path); err == nil {
+				continue
+			}
+
+			if image {
+				l.report(line+i, match[0]+1, SeverityError, RuleBrokenImage, "image not found: %s", target)
+			} else {
+				l.report(line+i, match[0]+1, SeverityError, RuleBrokenLink, "link target not found: %s", target)
+			}
+		}
+	}
+}
+
+// localPath resolves a link target to a file, or returns false for targets
+// that are not local files such as URLs and anchors
+func (l *linter) localPath(target string) (string, bool) {
+	if strings.HasPrefix(target, "#") {
+		return "", false
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil || parsed.Scheme != "" || parsed.Host != "" || parsed.Path == "" {
+		return "", false
+	}
+
+	path := filepath.FromSlash(parsed.Path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(l.dir, path)
+	}
+
+	return path, true
+}
+
+// checkEmptySlides reports separators with nothing but blank lines or
+// metadata between them. The parser drops such slides silently.
+func (l *linter) checkEmptySlides(tokens []data.Token) {
+	previous := 0
+	visible := false
+
+	for _, token := range tokens {
+		switch token.Kind {
+		case data.TokenSeparator:
+			if previous > 0 && !visible {
+				l.report(previous+l.offset, 1, SeverityWarning, RuleEmptySlide, "slide has no content")
+			}
+			previous = token.Line
+			visible = false
+
+		case data.TokenBlank, data.TokenComment:
+			// ? Comments are invisible, whether metadata or not
+
+		default:
+			if strings.TrimSpace(display.StripMetadataComments(token.Text())) != "" {
+				visible = true
+			}
+		}
+	}
+}
+
+// checkOverflow renders a slide and reports it when it would not fit on a
+// screen of the configured size
+func (l *linter) checkOverflow(renderer *display.Renderer, slide *models.Slide, index, line int) error {
+	if l.opts.MaxWidth <= 0 && l.opts.MaxHeight <= 0 {
+		return nil
+	}
+
+	rendered, err := renderer.RenderSlide(slide)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.Trim(rendered, "\n"), "\n")
+
+	if l.opts.MaxHeight > 0 && len(lines) > l.opts.MaxHeight {
+		l.report(line, 1, SeverityWarning, RuleOverflowHeight,
+			"slide %d is %d lines tall, more than %d", index+1, len(lines), l.opts.MaxHeight)
+	}
+
+	if l.opts.MaxWidth > 0 {
+		widest := 0
+		for _, text := range lines {
+			widest = max(widest, ansi.StringWidth(strings.TrimRight(ansi.Strip(text), " ")))
+		}
+		if widest > l.opts.MaxWidth {
+			l.report(line, 1, SeverityWarning, RuleOverflowWidth,
+				"slide %d is %d columns wide, more than %d", index+1, widest, l.opts.MaxWidth)
+		}
+	}
+
+	return nil
+}
+
+// checkColors reports slide colors that cannot be parsed, and text that
+// would be hard to read against the slide background
+func (l *linter) checkColors(renderer *display.Renderer, slide *models.Slide, section data.Section, line int) {
+	if err := display.SlideColorError(slide.Metadata); err != nil {
+		l.report(line+metadataLine(section.Content, "background", "foreground", "accent"), 1,
+			SeverityError, RuleInvalidColor, "%s", err)
+	}
+
+	if renderer == nil {
+		return
+	}
+	if ratio, low := renderer.LowContrast(slide); low {
+		l.report(line+metadataLine(section.Content, "background", "foreground", "accent"), 1,
+			SeverityWarning, RuleLowContrast, "low contrast between text and background (%.1f:1, want %.0f:1)",
+			ratio, theme.MinContrast)
+	}
+}
+
+func (l *linter) checkTransition(name string, line int) {
+	if name == "" {
+		return
+	}
+	if _, ok := display.ParseTransition(name); !ok {
+		l.report(line, 1, SeverityWarning, RuleUnknownTransition, "unknown transition %q", name)
+	}
+}
+
+// checkDuration reports a talk or slide length the timer cannot read
+func (l *linter) checkDuration(value string, line int) {
+	if _, err := timer.ParseDuration(value); err != nil {
+		l.report(line, 1, SeverityWarning, RuleInvalidDuration, "%s", err)
+	}
+}
+
+// checkPlacement reports an align or justify value slate does not know
+func (l *linter) checkPlacement(key, value string, valid []string, line int) {
+	if value != "" && !slices.Contains(valid, value) {
+		l.report(line, 1, SeverityWarning, RuleInvalidPlacement, "unknown %s %q, use %s", key, value, strings.Join(valid, ", "))
+	}
+}
+
+// checkBigText reports a big heading level other than h1 to h6 or off
+func (l *linter) checkBigText(key, value string, line int) {
+	if _, ok := display.BigTextLevel(value); ok || value == "" || value == "off" {
+		return
+	}
+	l.report(line, 1, SeverityWarning, RuleInvalidBigText, "unknown %s level %q, use h1 to h6 or off", key, value)
+}
+
+// checkColumns reports rows of columns that are left open or ask for more
+// than the width of the slide
+func (l *linter) checkColumns(content string, line int) {
+	for _, group := range data.ParseColumns(content) {
+		at := line + group.Line - 1
+		if !group.Closed {
+			l.report(at, 1, SeverityError, RuleInvalidColumns, "columns block is not closed with :::")
+		}
+		if len(group.Columns) == 0 {
+			l.report(at, 1, SeverityWarning, RuleInvalidColumns, "columns block has no ::: column inside")
+			continue
+		}
+
+		total, shared := 0, 0
+		for _, column := range group.Columns {
+			total += column.Width
+			if column.Width == 0 {
+				shared++
+			}
+		}
+		switch {
+		case total > 100:
+			l.report(at, 1, SeverityWarning, RuleInvalidColumns, "column widths add up to %d%%, more than the slide", total)
+		case total == 100 && shared > 0:
+			l.report(at, 1, SeverityWarning, RuleInvalidColumns, "columns without a width have no room left")
+		}
+	}
+}
+
+// frontMatterLine returns the line of a key within the front matter source
+func frontMatterLine(source, key string) int {
+	for i, line := range strings.Split(source, "\n") {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(line)), strings.ToLower(key)+":") {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// slotLine returns the line of the first slot template of a header or footer
+// within the front matter source
+func slotLine(source, name string) int {
+	for _, slot := range []string{"Left", "Center", "Right"} {
+		if line := frontMatterLine(source, name+slot); line > 0 {
+			return line
+		}
+	}
+	return 0
+}
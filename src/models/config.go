@@ -1,16 +1,87 @@
 package models
 
+import (
+	"strings"
+	"time"
+)
+
 type ThemeConfig struct {
 	Mode         string
 	GlamourStyle string
 	ShowProgress bool
 	ShowSlideNum bool
+	// Palette names the colors of the progress bar, header, footer, help
+	// and errors, one of the bundled palettes or one from Palettes
+	Palette string
+	// Colors override single colors of the palette
+	Colors ColorsConfig
+	// Palettes defines palettes of its own by name, each on top of the
+	// bundled palette of that name or the default one
+	Palettes map[string]ColorsConfig
+}
+
+// * ColorsConfig holds colors of the UI chrome, each a color name, an ANSI
+// 256 index or hex. Empty colors are taken from the palette.
+type ColorsConfig struct {
+	Primary     string
+	Secondary   string
+	Background  string
+	Foreground  string
+	Accent      string
+	Muted       string
+	Error       string
+	Success     string
+	Warning     string
+	Border      string
+	ProgressBar string
 }
 
 type PresentationConfig struct {
 	WordWrap int
 	Margin   int
 	Padding  int
+	// DisableAnimations shows slide changes at once, for slow connections
+	DisableAnimations bool
+	// Images picks how images are drawn: auto, kitty, iterm2, sixel, blocks
+	// or off
+	Images string
+	// Align and Justify place slide content unless the deck or slide says
+	// otherwise
+	Align   string
+	Justify string
+}
+
+// * SlotsConfig holds the templates shown at the left, center and right of
+// a header or footer bar
+type SlotsConfig struct {
+	Left   string
+	Center string
+	Right  string
+}
+
+type LayoutConfig struct {
+	Header SlotsConfig
+	Footer SlotsConfig
+}
+
+// * TimerConfig sets when the talk timer warns that time is running out
+type TimerConfig struct {
+	// Warning and Critical are the time left at which the timer turns
+	// yellow and then red
+	Warning  time.Duration
+	Critical time.Duration
+}
+
+// * ExecConfig controls running code blocks from slides
+type ExecConfig struct {
+	// Timeout stops blocks that run longer
+	Timeout time.Duration
+	// Commands maps a language to the command that runs it, where {file}
+	// stands for a file holding the code
+	Commands map[string]string
+	// Allow lists decks, or directories of decks, whose code runs without
+	// asking first
+	Allow []string
 }
 
 type KeybindingConfig struct {
@@ -24,9 +95,76 @@ type KeybindingConfig struct {
 type Config struct {
 	Theme        ThemeConfig
 	Presentation PresentationConfig
+	Layout       LayoutConfig
+	Timer        TimerConfig
+	Exec         ExecConfig
 	Keybindings  KeybindingConfig
 }
 
+// * Names of the chrome colors in the config and front matter, in the order
+// of the ColorsConfig fields
+var ColorNames = []string{
+	"primary", "secondary", "background", "foreground", "accent", "muted",
+	"error", "success", "warning", "border", "progressBar",
+}
+
+func (c *ColorsConfig) fields() []*string {
+	return []*string{
+		&c.Primary, &c.Secondary, &c.Background, &c.Foreground, &c.Accent, &c.Muted,
+		&c.Error, &c.Success, &c.Warning, &c.Border, &c.ProgressBar,
+	}
+}
+
+// Values returns the colors in the order of ColorNames
+func (c ColorsConfig) Values() []string {
+	fields := c.fields()
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = *field
+	}
+	return values
+}
+
+// Set sets a color by its name, in any case. It returns false for names
+// that are not colors.
+func (c *ColorsConfig) Set(name, value string) bool {
+	for i, field := range c.fields() {
+		if strings.EqualFold(ColorNames[i], name) {
+			*field = value
+			return true
+		}
+	}
+	return false
+}
+
+// Merge replaces the colors other sets
+func (c *ColorsConfig) Merge(other ColorsConfig) {
+	fields := c.fields()
+	for i, value := range other.Values() {
+		if value != "" {
+			*fields[i] = value
+		}
+	}
+}
+
+// IsEmpty reports whether no slot has a template
+func (s SlotsConfig) IsEmpty() bool {
+	return s.Left == "" && s.Center == "" && s.Right == ""
+}
+
+// Merge replaces the slots other sets
+func (s *SlotsConfig) Merge(other SlotsConfig) {
+	if other.Left != "" {
+		s.Left = other.Left
+	}
+	if other.Center != "" {
+		s.Center = other.Center
+	}
+	if other.Right != "" {
+		s.Right = other.Right
+	}
+}
+
 func NewDefaultConfig() *Config {
 	return &Config{
 		Theme: ThemeConfig{
@@ -39,6 +177,22 @@ func NewDefaultConfig() *Config {
 			WordWrap: 80,
 			Margin:   2,
 			Padding:  1,
+			Images:   "auto",
+		},
+		Timer: TimerConfig{
+			Warning:  5 * time.Minute,
+			Critical: time.Minute,
+		},
+		Exec: ExecConfig{
+			Timeout: 30 * time.Second,
+			Commands: map[string]string{
+				"go":         "go run {file}",
+				"python":     "python3 {file}",
+				"javascript": "node {file}",
+				"ruby":       "ruby {file}",
+				"bash":       "bash {file}",
+				"sh":         "sh {file}",
+			},
 		},
 		Keybindings: KeybindingConfig{
 			Next:     []string{"right", "space", "l"},
@@ -64,6 +218,18 @@ func (c *Config) Merge(other *Config) {
 	}
 	c.Theme.ShowProgress = other.Theme.ShowProgress
 	c.Theme.ShowSlideNum = other.Theme.ShowSlideNum
+	if other.Theme.Palette != "" {
+		c.Theme.Palette = strings.ToLower(other.Theme.Palette)
+	}
+	c.Theme.Colors.Merge(other.Theme.Colors)
+
+	// ? Palette names are case-insensitive, like the names they are picked by
+	if len(other.Theme.Palettes) > 0 && c.Theme.Palettes == nil {
+		c.Theme.Palettes = make(map[string]ColorsConfig)
+	}
+	for name, colors := range other.Theme.Palettes {
+		c.Theme.Palettes[strings.ToLower(name)] = colors
+	}
 
 	// * Merge presentation config
 	if other.Presentation.WordWrap > 0 {
@@ -75,6 +241,44 @@ func (c *Config) Merge(other *Config) {
 	if other.Presentation.Padding >= 0 {
 		c.Presentation.Padding = other.Presentation.Padding
 	}
+	if other.Presentation.DisableAnimations {
+		c.Presentation.DisableAnimations = true
+	}
+	if other.Presentation.Images != "" {
+		c.Presentation.Images = other.Presentation.Images
+	}
+	if other.Presentation.Align != "" {
+		c.Presentation.Align = other.Presentation.Align
+	}
+	if other.Presentation.Justify != "" {
+		c.Presentation.Justify = other.Presentation.Justify
+	}
+
+	// * Merge header and footer templates
+	c.Layout.Header.Merge(other.Layout.Header)
+	c.Layout.Footer.Merge(other.Layout.Footer)
+
+	// * Merge timer thresholds
+	if other.Timer.Warning > 0 {
+		c.Timer.Warning = other.Timer.Warning
+	}
+	if other.Timer.Critical > 0 {
+		c.Timer.Critical = other.Timer.Critical
+	}
+
+	// * Merge code execution, adding languages to the default commands
+	if other.Exec.Timeout > 0 {
+		c.Exec.Timeout = other.Exec.Timeout
+	}
+	if len(other.Exec.Commands) > 0 && c.Exec.Commands == nil {
+		c.Exec.Commands = make(map[string]string)
+	}
+	for language, command := range other.Exec.Commands {
+		c.Exec.Commands[strings.ToLower(language)] = command
+	}
+	if len(other.Exec.Allow) > 0 {
+		c.Exec.Allow = other.Exec.Allow
+	}
 
 	// * Merge keybindings
 	if len(other.Keybindings.Next) > 0 {
@@ -193,3 +193,53 @@ func TestKeybindingConfig(t *testing.T) {
 		t.Errorf("Expected 2 Quit keybindings, got %d", len(keybindings.Quit))
 	}
 }
+
+func TestConfigMergeExec(t *testing.T) {
+	config := NewDefaultConfig()
+
+	config.Merge(&Config{
+		Exec: ExecConfig{
+			Commands: map[string]string{"Rust": "rust-script {file}", "python": "python3.12 {file}"},
+			Allow:    []string{"~/talks"},
+		},
+	})
+
+	if config.Exec.Commands["go"] != "go run {file}" {
+		t.Errorf("Expected default go command to be kept, got %q", config.Exec.Commands["go"])
+	}
+	if config.Exec.Commands["rust"] != "rust-script {file}" {
+		t.Errorf("Expected rust command to be added, got %q", config.Exec.Commands["rust"])
+	}
+	if config.Exec.Commands["python"] != "python3.12 {file}" {
+		t.Errorf("Expected python command to be replaced, got %q", config.Exec.Commands["python"])
+	}
+	if config.Exec.Timeout == 0 {
+		t.Error("Expected default timeout to be kept")
+	}
+	if len(config.Exec.Allow) != 1 {
+		t.Errorf("Expected allow list to be set, got %v", config.Exec.Allow)
+	}
+}
+
+func TestConfigMergeColors(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Theme.Colors.Primary = "#123456"
+
+	config.Merge(&Config{
+		Theme: ThemeConfig{
+			Palette:  "Brand",
+			Colors:   ColorsConfig{ProgressBar: "#ff6600"},
+			Palettes: map[string]ColorsConfig{"Brand": {Primary: "#003366"}},
+		},
+	})
+
+	if config.Theme.Palette != "brand" {
+		t.Errorf("Expected palette 'brand', got '%s'", config.Theme.Palette)
+	}
+	if config.Theme.Colors.Primary != "#123456" || config.Theme.Colors.ProgressBar != "#ff6600" {
+		t.Errorf("Expected colors to merge field by field, got %+v", config.Theme.Colors)
+	}
+	if config.Theme.Palettes["brand"].Primary != "#003366" {
+		t.Errorf("Expected palette 'brand' to be defined, got %v", config.Theme.Palettes)
+	}
+}
@@ -2,16 +2,47 @@ package models
 
 import (
 	"errors"
+	"strings"
 	"time"
 )
 
 type Presentation struct {
 	FilePath string
 	Title    string
-	Author   string
-	Date     time.Time
-	Slides   []*Slide
-	Config   *Config
+	Subtitle string
+	// Author lists all authors, separated by commas
+	Author      string
+	Authors     []string
+	Affiliation string
+	Event       string
+	Date        time.Time
+	Slides      []*Slide
+	Config      *Config
+	// Transition is the default transition for slides that set none
+	Transition string
+	// Duration is how long the talk should take, such as 20m
+	Duration string
+	// Align and Justify place the content of slides that set neither
+	Align   string
+	Justify string
+	// GlamourStyle overrides the configured glamour style, as a style name
+	// or a path to a JSON style relative to the deck
+	GlamourStyle string
+	// BigHeadings draws headings up to this level, such as h1, in large
+	// letters on slides that do not set @bigtext
+	BigHeadings string
+	// Palette and Colors override the configured colors of the UI chrome
+	Palette string
+	Colors  ColorsConfig
+	// Header and Footer override the configured bar templates
+	Header SlotsConfig
+	Footer SlotsConfig
+
+	// * Generated cover and closing slides, with optional template files
+	TitleSlide      bool
+	ClosingSlide    bool
+	TitleTemplate   string
+	ClosingTemplate string
 }
 
 func NewPresentation(filePath string) *Presentation {
@@ -47,8 +78,74 @@ func (p *Presentation) SetMetadata(metadata map[string]string) {
 		p.Title = title
 	}
 
-	if author, ok := metadata["author"]; ok {
-		p.Author = author
+	if subtitle, ok := metadata["subtitle"]; ok {
+		p.Subtitle = subtitle
+	}
+
+	// ? Authors may be one name or a list, which arrives one name per line
+	for _, key := range []string{"author", "authors"} {
+		if authors, ok := metadata[key]; ok {
+			p.Authors = splitLines(authors)
+			p.Author = strings.Join(p.Authors, ", ")
+		}
+	}
+
+	if affiliation, ok := metadata["affiliation"]; ok {
+		p.Affiliation = affiliation
+	}
+
+	if event, ok := metadata["event"]; ok {
+		p.Event = event
+	}
+
+	p.Header = SlotsConfig{
+		Left:   metadata["headerleft"],
+		Center: metadata["headercenter"],
+		Right:  metadata["headerright"],
+	}
+	p.Footer = SlotsConfig{
+		Left:   metadata["footerleft"],
+		Center: metadata["footercenter"],
+		Right:  metadata["footerright"],
+	}
+
+	p.TitleSlide = metadata["titleslide"] == "true"
+	p.ClosingSlide = metadata["closingslide"] == "true"
+	p.TitleTemplate = metadata["titletemplate"]
+	p.ClosingTemplate = metadata["closingtemplate"]
+
+	if transition, ok := metadata["transition"]; ok {
+		p.Transition = strings.ToLower(transition)
+	}
+
+	if duration, ok := metadata["duration"]; ok {
+		p.Duration = duration
+	}
+
+	if align, ok := metadata["align"]; ok {
+		p.Align = strings.ToLower(align)
+	}
+	if justify, ok := metadata["justify"]; ok {
+		p.Justify = strings.ToLower(justify)
+	}
+
+	if glamourStyle, ok := metadata["glamourstyle"]; ok {
+		p.GlamourStyle = glamourStyle
+	}
+
+	if bigHeadings, ok := metadata["bigheadings"]; ok {
+		p.BigHeadings = strings.ToLower(bigHeadings)
+	}
+
+	if palette, ok := metadata["palette"]; ok {
+		p.Palette = strings.ToLower(palette)
+	}
+
+	// ? Colors are nested in the front matter, under colors:
+	for key, value := range metadata {
+		if name, ok := strings.CutPrefix(key, "colors."); ok {
+			p.Colors.Set(name, value)
+		}
 	}
 
 	if dateStr, ok := metadata["date"]; ok {
@@ -58,6 +155,28 @@ func (p *Presentation) SetMetadata(metadata map[string]string) {
 	}
 }
 
+// Layout returns the header and footer templates, with the deck's own
+// overriding the configured ones slot by slot
+func (p *Presentation) Layout() LayoutConfig {
+	var layout LayoutConfig
+	if p.Config != nil {
+		layout = p.Config.Layout
+	}
+	layout.Header.Merge(p.Header)
+	layout.Footer.Merge(p.Footer)
+	return layout
+}
+
+func splitLines(value string) []string {
+	lines := make([]string, 0)
+	for line := range strings.SplitSeq(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 func (p *Presentation) GetMetadata() map[string]string {
 	metadata := make(map[string]string)
 
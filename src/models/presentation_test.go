@@ -114,9 +114,10 @@ func TestSetMetadata(t *testing.T) {
 	p := NewPresentation("test.md")
 
 	metadata := map[string]string{
-		"title":  "New Title",
-		"author": "New Author",
-		"date":   "2025-12-25",
+		"title":      "New Title",
+		"author":     "New Author",
+		"date":       "2025-12-25",
+		"transition": "Fade",
 	}
 
 	p.SetMetadata(metadata)
@@ -133,6 +134,10 @@ func TestSetMetadata(t *testing.T) {
 	if !p.Date.Equal(expectedDate) {
 		t.Errorf("Expected date %v, got %v", expectedDate, p.Date)
 	}
+
+	if p.Transition != "fade" {
+		t.Errorf("Expected transition 'fade', got '%s'", p.Transition)
+	}
 }
 
 func TestValidate(t *testing.T) {
@@ -182,3 +187,30 @@ func TestPresentationIsEmpty(t *testing.T) {
 		t.Error("Expected presentation to not be empty")
 	}
 }
+
+func TestPresentationLayout(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Layout.Footer = SlotsConfig{Left: "ACME", Right: "{{ .SlideNumber }}"}
+
+	p := NewPresentation("test.md")
+	p.Config = config
+	p.SetMetadata(map[string]string{
+		"footerleft":  "{{ .Event }}",
+		"headerright": "{{ .Section }}",
+	})
+
+	layout := p.Layout()
+
+	if layout.Footer.Left != "{{ .Event }}" {
+		t.Errorf("Expected deck footer to override config, got '%s'", layout.Footer.Left)
+	}
+	if layout.Footer.Right != "{{ .SlideNumber }}" {
+		t.Errorf("Expected config footer slot to be kept, got '%s'", layout.Footer.Right)
+	}
+	if layout.Header.Right != "{{ .Section }}" || layout.Header.Left != "" {
+		t.Errorf("Expected header from deck only, got %+v", layout.Header)
+	}
+	if config.Layout.Footer.Left != "ACME" {
+		t.Error("Expected config to be left unchanged")
+	}
+}
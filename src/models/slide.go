@@ -5,14 +5,85 @@ import "strings"
 type SlideMetadata struct {
 	Notes      string
 	Transition string
+	// Background, Foreground and Accent color the slide, as a color name,
+	// ANSI 256 index or hex value
 	Background string
+	Foreground string
+	Accent     string
+	// Time is how long the slide should take, such as 90s
+	Time string
+	// Align places the content vertically (top, center or bottom) and
+	// Justify horizontally (left, center or right)
+	Align   string
+	Justify string
+	// BigText draws headings up to this level, such as h1, in large
+	// letters, or is off when the slide opts out of the deck's setting
+	BigText string
+	// Incremental reveals each top-level list item as its own fragment
+	Incremental bool
+	// Terminal hosts an interactive terminal below the slide, running
+	// TerminalCommand or the user's shell
+	Terminal        bool
+	TerminalCommand string
+	// HideHeader and HideFooter blank the header and footer bars
+	HideHeader bool
+	HideFooter bool
+	// Extra holds metadata keys without a field of their own
+	Extra map[string]string
+}
+
+// * SlideKind tells slides written in the deck from generated ones
+type SlideKind int
+
+const (
+	SlideContent SlideKind = iota
+	// SlideTitle is the cover slide generated from the front matter
+	SlideTitle
+	// SlideClosing is the closing slide generated from the front matter
+	SlideClosing
+)
+
+// * CodeBlock is a fenced code block marked to run live from the slide
+type CodeBlock struct {
+	Language string
+	Code     string
+	// Source is the fenced block as written, so its output can be shown
+	// right below it
+	Source string
+}
+
+// * Column is one column of a multi-column layout
+type Column struct {
+	// Width is the share of the slide width in percent, 0 shares what the
+	// other columns leave
+	Width   int
+	Content string
+}
+
+// * ColumnGroup is a row of columns written as a ::: columns block
+type ColumnGroup struct {
+	Columns []Column
+	// Line and EndLine are the 1-based lines of the block's markers within
+	// the slide
+	Line    int
+	EndLine int
+	// Closed reports whether the block was ended with :::
+	Closed bool
 }
 
 type Slide struct {
 	Index         int
+	Kind          SlideKind
 	RawContent    string
 	RenderedCache string
-	Metadata      SlideMetadata
+	FragmentCache map[int]string
+	// Fragments holds the source chunks revealed one step at a time
+	Fragments []string
+	Metadata  SlideMetadata
+	// CodeBlocks holds the code blocks that can be run, in slide order
+	CodeBlocks []CodeBlock
+	// Columns holds the slide's multi-column layouts
+	Columns []ColumnGroup
 }
 
 func NewSlide(index int, content string) *Slide {
@@ -23,6 +94,11 @@ func NewSlide(index int, content string) *Slide {
 	}
 }
 
+// IsGenerated reports whether slate made the slide rather than the deck
+func (s *Slide) IsGenerated() bool {
+	return s.Kind != SlideContent
+}
+
 func (s *Slide) IsEmpty() bool {
 	return strings.TrimSpace(s.RawContent) == ""
 }
@@ -31,6 +107,79 @@ func (s *Slide) Content() string {
 	return s.RawContent
 }
 
+// Title returns the text of the first ATX heading outside code fences
+func (s *Slide) Title() string {
+	title, _ := s.Heading()
+	return title
+}
+
+// Heading returns the text and level of the first ATX heading outside code
+// fences, or a level of 0 when the slide has none
+func (s *Slide) Heading() (string, int) {
+	fence := ""
+
+	for _, line := range strings.Split(s.RawContent, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// ? A fence only closes on a run of its own character at least as
+		// long as the one that opened it, as the parser reads it
+		if fence != "" {
+			if len(trimmed) >= len(fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if fence = fenceMarker(trimmed); fence != "" {
+			continue
+		}
+
+		if !strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		heading := strings.TrimLeft(trimmed, "#")
+		if heading == "" || heading[0] == ' ' || heading[0] == '\t' {
+			level := len(trimmed) - len(heading)
+			return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(heading), "#")), level
+		}
+	}
+
+	return "", 0
+}
+
+// fenceMarker returns the run of backticks or tildes that opens a code fence
+// on line, or an empty string when the line opens none
+func fenceMarker(line string) string {
+	if !strings.HasPrefix(line, "```") && !strings.HasPrefix(line, "~~~") {
+		return ""
+	}
+	marker := line[:len(line)-len(strings.TrimLeft(line, line[:1]))]
+
+	// ? Backtick fences cannot carry backticks in their info string
+	if marker[0] == '`' && strings.Contains(line[len(marker):], "`") {
+		return ""
+	}
+	return marker
+}
+
+func (s *Slide) FragmentCount() int {
+	return max(len(s.Fragments), 1)
+}
+
+func (s *Slide) IsLastFragment(fragment int) bool {
+	return fragment >= s.FragmentCount()-1
+}
+
+// FragmentContent returns the source visible once the given fragment is revealed
+func (s *Slide) FragmentContent(fragment int) string {
+	if len(s.Fragments) == 0 || s.IsLastFragment(fragment) {
+		return s.RawContent
+	}
+
+	fragment = max(fragment, 0)
+	return strings.TrimSpace(strings.Join(s.Fragments[:fragment+1], "\n"))
+}
+
 func (s *Slide) SetRenderedCache(rendered string) {
 	s.RenderedCache = rendered
 }
@@ -43,6 +192,22 @@ func (s *Slide) HasCache() bool {
 	return s.RenderedCache != ""
 }
 
+func (s *Slide) SetFragmentCache(fragment int, rendered string) {
+	if s.FragmentCache == nil {
+		s.FragmentCache = make(map[int]string)
+	}
+	s.FragmentCache[fragment] = rendered
+}
+
+func (s *Slide) GetFragmentCache(fragment int) string {
+	return s.FragmentCache[fragment]
+}
+
+func (s *Slide) HasFragmentCache(fragment int) bool {
+	return s.FragmentCache[fragment] != ""
+}
+
 func (s *Slide) ClearCache() {
 	s.RenderedCache = ""
+	s.FragmentCache = nil
 }
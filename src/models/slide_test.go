@@ -131,3 +131,87 @@ func TestSlideMetadata(t *testing.T) {
 		t.Errorf("Expected background '#000000', got '%s'", slide.Metadata.Background)
 	}
 }
+
+func TestSlideFragments(t *testing.T) {
+	slide := NewSlide(0, "# Title\n\n<!-- @pause -->\n\n- One\n- Two")
+
+	if slide.FragmentCount() != 1 {
+		t.Errorf("Expected 1 fragment without Fragments set, got %d", slide.FragmentCount())
+	}
+
+	slide.Fragments = []string{"# Title\n", "\n- One\n- Two"}
+
+	if slide.FragmentCount() != 2 {
+		t.Errorf("Expected 2 fragments, got %d", slide.FragmentCount())
+	}
+
+	if slide.FragmentContent(0) != "# Title" {
+		t.Errorf("Expected first fragment '# Title', got '%s'", slide.FragmentContent(0))
+	}
+
+	if slide.FragmentContent(1) != slide.RawContent {
+		t.Errorf("Expected last fragment to show the whole slide, got '%s'", slide.FragmentContent(1))
+	}
+
+	if slide.IsLastFragment(0) {
+		t.Error("Expected fragment 0 not to be the last fragment")
+	}
+
+	if !slide.IsLastFragment(1) {
+		t.Error("Expected fragment 1 to be the last fragment")
+	}
+}
+
+func TestFragmentCache(t *testing.T) {
+	slide := NewSlide(0, "# Test")
+
+	if slide.HasFragmentCache(0) {
+		t.Error("Expected no fragment cache initially")
+	}
+
+	slide.SetFragmentCache(0, "Partial")
+	slide.SetRenderedCache("Full")
+
+	if slide.GetFragmentCache(0) != "Partial" {
+		t.Errorf("Expected fragment cache 'Partial', got '%s'", slide.GetFragmentCache(0))
+	}
+
+	slide.ClearCache()
+
+	if slide.HasFragmentCache(0) || slide.HasCache() {
+		t.Error("Expected ClearCache to drop fragment and slide caches")
+	}
+}
+
+func TestSlideTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		level    int
+	}{
+		{"H1", "# Welcome\n\nBody", "Welcome", 1},
+		{"H2 after text", "Intro\n\n## Details ##", "Details", 2},
+		{"Skips fenced comments", "```bash\n# not a title\n```\n\n# Real", "Real", 1},
+		{"Shorter fence inside a longer one", "````md\n```\n# not a title\n````\n\n# Real", "Real", 1},
+		{"Backticks inside a tilde fence", "~~~\n```\n# not a title\n~~~\n\n# Real", "Real", 1},
+		{"Tildes do not close a backtick fence", "```\n~~~\n# not a title\n```\n\n# Real", "Real", 1},
+		{"Fence closed with a longer run", "```\n# not a title\n`````\n\n# Real", "Real", 1},
+		{"Inline code is not a fence", "``` `code` ```\n\n# Real", "Real", 1},
+		{"Unclosed fence hides the rest", "```\n# not a title", "", 0},
+		{"Hashtag is not a heading", "#hashtag\n\nBody", "", 0},
+		{"No heading", "Just text", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slide := NewSlide(0, tt.content)
+			if slide.Title() != tt.expected {
+				t.Errorf("Expected title '%s', got '%s'", tt.expected, slide.Title())
+			}
+			if _, level := slide.Heading(); level != tt.level {
+				t.Errorf("Expected heading level %d, got %d", tt.level, level)
+			}
+		})
+	}
+}
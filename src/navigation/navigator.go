@@ -1,30 +1,58 @@
 package navigation
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/Kosha-Nirman/slate/src/models"
 )
 
+// * Position identifies a slide and how many of its fragments are revealed
+type Position struct {
+	Slide    int
+	Fragment int
+}
+
 type Navigator struct {
-	presentation *models.Presentation
-	currentIndex int
-	history      []int
-	maxHistory   int
+	presentation    *models.Presentation
+	currentIndex    int
+	currentFragment int
+	history         []Position
+	maxHistory      int
 }
 
 func New(presentation *models.Presentation) *Navigator {
 	return &Navigator{
 		presentation: presentation,
 		currentIndex: 0,
-		history:      make([]int, 0),
+		history:      make([]Position, 0),
 		maxHistory:   100,
 	}
 }
 
+// SetPresentation swaps in a reloaded presentation and moves to the given
+// slide, dropping history entries that no longer exist
+func (n *Navigator) SetPresentation(presentation *models.Presentation, index int) {
+	n.presentation = presentation
+
+	count := presentation.SlideCount()
+	n.currentIndex = min(max(index, 0), max(count-1, 0))
+	n.currentFragment = min(n.currentFragment, n.FragmentCount()-1)
+
+	history := make([]Position, 0, len(n.history))
+	for _, position := range n.history {
+		if position.Slide < count {
+			history = append(history, position)
+		}
+	}
+	n.history = history
+}
+
 func (n *Navigator) recordHistory() {
 	// * Add current position to history
-	n.history = append(n.history, n.currentIndex)
+	n.history = append(n.history, n.Position())
 
 	// * Trim history if it exceeds max size
 	if len(n.history) > n.maxHistory {
@@ -32,19 +60,44 @@ func (n *Navigator) recordHistory() {
 	}
 }
 
+func (n *Navigator) moveTo(index int) {
+	n.recordHistory()
+	n.currentIndex = index
+	n.currentFragment = 0
+}
+
+func (n *Navigator) fragmentCount(index int) int {
+	slide, err := n.presentation.GetSlide(index)
+	if err != nil {
+		return 1
+	}
+	return slide.FragmentCount()
+}
+
 func (n *Navigator) Next() bool {
+	// ? Reveal the next fragment before leaving the slide
+	if n.HasNextFragment() {
+		n.currentFragment++
+		return true
+	}
+
 	if n.currentIndex < n.presentation.SlideCount()-1 {
-		n.recordHistory()
-		n.currentIndex++
+		n.moveTo(n.currentIndex + 1)
 		return true
 	}
 	return false
 }
 
 func (n *Navigator) Previous() bool {
+	if n.currentFragment > 0 {
+		n.currentFragment--
+		return true
+	}
+
+	// * Stepping back lands on the fully revealed previous slide
 	if n.currentIndex > 0 {
-		n.recordHistory()
-		n.currentIndex--
+		n.moveTo(n.currentIndex - 1)
+		n.currentFragment = n.fragmentCount(n.currentIndex) - 1
 		return true
 	}
 	return false
@@ -52,8 +105,7 @@ func (n *Navigator) Previous() bool {
 
 func (n *Navigator) First() bool {
 	if n.currentIndex != 0 {
-		n.recordHistory()
-		n.currentIndex = 0
+		n.moveTo(0)
 		return true
 	}
 	return false
@@ -62,8 +114,7 @@ func (n *Navigator) First() bool {
 func (n *Navigator) Last() bool {
 	lastIndex := n.presentation.SlideCount() - 1
 	if n.currentIndex != lastIndex {
-		n.recordHistory()
-		n.currentIndex = lastIndex
+		n.moveTo(lastIndex)
 		return true
 	}
 	return false
@@ -75,10 +126,19 @@ func (n *Navigator) GoTo(index int) error {
 	}
 
 	if n.currentIndex != index {
-		n.recordHistory()
-		n.currentIndex = index
+		n.moveTo(index)
+	}
+
+	return nil
+}
+
+// SetPosition moves to a slide with the given number of fragments revealed
+func (n *Navigator) SetPosition(position Position) error {
+	if err := n.GoTo(position.Slide); err != nil {
+		return err
 	}
 
+	n.currentFragment = min(max(position.Fragment, 0), n.FragmentCount()-1)
 	return nil
 }
 
@@ -89,10 +149,11 @@ func (n *Navigator) GoToSlideNumber(slideNum int) error {
 func (n *Navigator) Back() bool {
 	if len(n.history) > 0 {
 		// * Pop from history
-		lastIndex := n.history[len(n.history)-1]
+		last := n.history[len(n.history)-1]
 		n.history = n.history[:len(n.history)-1]
 
-		n.currentIndex = lastIndex
+		n.currentIndex = last.Slide
+		n.currentFragment = min(last.Fragment, n.fragmentCount(last.Slide)-1)
 		return true
 	}
 	return false
@@ -102,6 +163,18 @@ func (n *Navigator) CurrentIndex() int {
 	return n.currentIndex
 }
 
+func (n *Navigator) CurrentFragment() int {
+	return n.currentFragment
+}
+
+func (n *Navigator) FragmentCount() int {
+	return n.fragmentCount(n.currentIndex)
+}
+
+func (n *Navigator) Position() Position {
+	return Position{Slide: n.currentIndex, Fragment: n.currentFragment}
+}
+
 func (n *Navigator) CurrentSlideNumber() int {
 	return n.currentIndex + 1
 }
@@ -122,6 +195,15 @@ func (n *Navigator) HasPrevious() bool {
 	return n.currentIndex > 0
 }
 
+func (n *Navigator) HasNextFragment() bool {
+	return n.currentFragment < n.FragmentCount()-1
+}
+
+// AtEnd reports whether the last fragment of the last slide is showing
+func (n *Navigator) AtEnd() bool {
+	return n.IsLast() && !n.HasNextFragment()
+}
+
 func (n *Navigator) IsFirst() bool {
 	return n.currentIndex == 0
 }
@@ -142,7 +224,7 @@ func (n *Navigator) ProgressText() string {
 }
 
 func (n *Navigator) ClearHistory() {
-	n.history = make([]int, 0)
+	n.history = make([]Position, 0)
 }
 
 func (n *Navigator) HistorySize() int {
@@ -151,6 +233,7 @@ func (n *Navigator) HistorySize() int {
 
 func (n *Navigator) Reset() {
 	n.currentIndex = 0
+	n.currentFragment = 0
 	n.ClearHistory()
 }
 
@@ -168,8 +251,7 @@ func (n *Navigator) JumpForward(count int) bool {
 		return false
 	}
 
-	n.recordHistory()
-	n.currentIndex = targetIndex
+	n.moveTo(targetIndex)
 	return true
 }
 
@@ -179,7 +261,46 @@ func (n *Navigator) JumpBackward(count int) bool {
 		return false
 	}
 
-	n.recordHistory()
-	n.currentIndex = targetIndex
+	n.moveTo(targetIndex)
 	return true
 }
+
+// Resolve turns jump prompt input into a slide index. It accepts a slide
+// number ("12"), a relative offset ("+3", "-2") or part of a slide title.
+func (n *Navigator) Resolve(target string) (int, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return 0, errors.New("enter a slide number, title or offset")
+	}
+
+	// * Relative offsets and absolute slide numbers
+	if number, err := strconv.Atoi(target); err == nil {
+		index := number - 1
+		if strings.HasPrefix(target, "+") || strings.HasPrefix(target, "-") {
+			index = n.currentIndex + number
+		}
+
+		if !n.CanNavigate(index) {
+			return 0, fmt.Errorf("no slide %s (1-%d)", target, n.TotalSlides())
+		}
+		return index, nil
+	}
+
+	// * Prefer exact titles, then prefixes, then any match
+	query := strings.ToLower(target)
+	matchers := []func(title string) bool{
+		func(title string) bool { return title == query },
+		func(title string) bool { return strings.HasPrefix(title, query) },
+		func(title string) bool { return strings.Contains(title, query) },
+	}
+
+	for _, matches := range matchers {
+		for i, slide := range n.presentation.Slides {
+			if matches(strings.ToLower(slide.Title())) {
+				return i, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("no slide titled %q", target)
+}
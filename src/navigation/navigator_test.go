@@ -0,0 +1,128 @@
+package navigation
+
+import (
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+func newTestNavigator() *Navigator {
+	intro := models.NewSlide(0, "# Introduction\n\n- One\n- Two")
+	intro.Fragments = []string{"# Introduction\n", "- One", "- Two"}
+
+	return New(&models.Presentation{
+		Slides: []*models.Slide{
+			intro,
+			models.NewSlide(1, "# Roadmap"),
+			models.NewSlide(2, "# Road trip"),
+			models.NewSlide(3, "# Questions"),
+		},
+	})
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  int
+		target   string
+		expected int
+		err      bool
+	}{
+		{"Slide number", 0, "3", 2, false},
+		{"Slide number with spaces", 0, " 2 ", 1, false},
+		{"Forward offset", 1, "+2", 3, false},
+		{"Backward offset", 3, "-2", 1, false},
+		{"Exact title", 0, "road trip", 2, false},
+		{"Title prefix", 0, "road", 1, false},
+		{"Title part", 0, "trip", 2, false},
+		{"Title ignores case", 0, "QUESTIONS", 3, false},
+		{"Exact title wins over prefix", 0, "Roadmap", 1, false},
+		{"Number out of range", 0, "5", 0, true},
+		{"Zero", 0, "0", 0, true},
+		{"Offset out of range", 3, "+1", 0, true},
+		{"Unknown title", 0, "summary", 0, true},
+		{"Empty", 0, "  ", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := newTestNavigator()
+			if err := n.GoTo(tt.current); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			got, err := n.Resolve(tt.target)
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if err == nil && got != tt.expected {
+				t.Errorf("Expected slide %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFragmentNavigation(t *testing.T) {
+	n := newTestNavigator()
+
+	tests := []struct {
+		name     string
+		step     func() bool
+		moved    bool
+		expected Position
+		atEnd    bool
+	}{
+		{"Reveal second fragment", n.Next, true, Position{0, 1}, false},
+		{"Reveal last fragment", n.Next, true, Position{0, 2}, false},
+		{"Leave the slide", n.Next, true, Position{1, 0}, false},
+		{"Back to the revealed slide", n.Previous, true, Position{0, 2}, false},
+		{"Hide a fragment", n.Previous, true, Position{0, 1}, false},
+		{"Hide another fragment", n.Previous, true, Position{0, 0}, false},
+		{"Stop at the start", n.Previous, false, Position{0, 0}, false},
+		{"Jump to the end", n.Last, true, Position{3, 0}, true},
+		{"Stop at the end", n.Next, false, Position{3, 0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if moved := tt.step(); moved != tt.moved {
+				t.Errorf("Expected moved %v, got %v", tt.moved, moved)
+			}
+			if got := n.Position(); got != tt.expected {
+				t.Errorf("Expected position %v, got %v", tt.expected, got)
+			}
+			if n.AtEnd() != tt.atEnd {
+				t.Errorf("Expected AtEnd %v, got %v", tt.atEnd, n.AtEnd())
+			}
+		})
+	}
+}
+
+func TestAtEndWaitsForFragments(t *testing.T) {
+	last := models.NewSlide(0, "# Done\n\n- One\n- Two")
+	last.Fragments = []string{"# Done\n", "- One\n- Two"}
+	n := New(&models.Presentation{Slides: []*models.Slide{last}})
+
+	if n.AtEnd() {
+		t.Error("Expected AtEnd to be false before the last fragment")
+	}
+	n.Next()
+	if !n.AtEnd() {
+		t.Error("Expected AtEnd to be true on the last fragment")
+	}
+}
+
+func TestSetPositionClampsFragment(t *testing.T) {
+	n := newTestNavigator()
+
+	if err := n.SetPosition(Position{Slide: 0, Fragment: 9}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := n.Position(); got != (Position{0, 2}) {
+		t.Errorf("Expected position {0 2}, got %v", got)
+	}
+
+	if err := n.SetPosition(Position{Slide: 4}); err == nil {
+		t.Error("Expected error for a slide out of range")
+	}
+}
@@ -0,0 +1,67 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+)
+
+// * Client connects a presenter console to a running presentation
+type Client struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	encoder *json.Encoder
+}
+
+func Dial(filePath string) (*Client, error) {
+	path, err := SocketPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("no running presentation for %s (start it with 'slate present --presenter %s')", filePath, filePath)
+	}
+
+	return &Client{
+		conn:    conn,
+		scanner: bufio.NewScanner(conn),
+		encoder: json.NewEncoder(conn),
+	}, nil
+}
+
+// Send asks the presentation to navigate; the resulting position arrives
+// through Receive like any other state update
+func (c *Client) Send(command string, slide int) error {
+	return c.encoder.Encode(Message{
+		Type:    MessageCommand,
+		Command: command,
+		Slide:   slide,
+	})
+}
+
+// Receive blocks until the next state update from the presentation
+func (c *Client) Receive() (Message, error) {
+	for c.scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(c.scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+
+		if msg.Type == MessageState {
+			return msg, nil
+		}
+	}
+
+	if err := c.scanner.Err(); err != nil {
+		return Message{}, fmt.Errorf("connection lost: %w", err)
+	}
+
+	return Message{}, fmt.Errorf("presentation ended")
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,28 @@
+//go:build unix
+
+package remote
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// userDirName names the fallback socket directory after the user, so users
+// sharing a temporary directory each get their own
+func userDirName() string {
+	return fmt.Sprintf("slate-%d", os.Getuid())
+}
+
+// checkPrivateDir makes sure only the current user can use dir, so no one
+// else can plant a socket for a presentation to connect to
+func checkPrivateDir(dir string, info os.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("socket directory %s is not owned by the current user", dir)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return fmt.Errorf("socket directory %s is accessible by other users", dir)
+	}
+	return nil
+}
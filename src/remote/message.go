@@ -0,0 +1,87 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/timer"
+)
+
+// * MessageType distinguishes state updates from navigation commands
+type MessageType string
+
+const (
+	MessageState   MessageType = "state"
+	MessageCommand MessageType = "command"
+)
+
+// * Navigation commands a client can send to the presentation
+const (
+	CommandNext     = "next"
+	CommandPrevious = "previous"
+	CommandFirst    = "first"
+	CommandLast     = "last"
+	CommandBack     = "back"
+	CommandGoTo     = "goto"
+)
+
+// * Message is exchanged as newline-delimited JSON over the socket
+type Message struct {
+	Type     MessageType `json:"type"`
+	Command  string      `json:"command,omitempty"`
+	Slide    int         `json:"slide"`
+	Fragment int         `json:"fragment"`
+	// Elapsed, Paused and Plan carry the talk timer, so consoles show the
+	// same time and pace as the audience view
+	Elapsed time.Duration `json:"elapsed,omitempty"`
+	Paused  bool          `json:"paused,omitempty"`
+	Plan    timer.Plan    `json:"plan,omitzero"`
+}
+
+// SocketPath returns the socket used to synchronize views of a presentation
+// file. Both sides derive it from the absolute file path so they meet without
+// any extra configuration.
+func SocketPath(filePath string) (string, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", fmt.Errorf("invalid presentation path: %w", err)
+	}
+
+	dir, err := socketDir()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(absPath))
+	return filepath.Join(dir, fmt.Sprintf("slate-%x.sock", sum[:8])), nil
+}
+
+// socketDir returns a directory only the current user can use, preferring
+// the runtime directory of the session
+func socketDir() (string, error) {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" && filepath.IsAbs(dir) {
+		return dir, nil
+	}
+
+	dir := filepath.Join(os.TempDir(), userDirName())
+	if err := os.Mkdir(dir, 0o700); err != nil && !os.IsExist(err) {
+		return "", fmt.Errorf("failed to create socket directory: %w", err)
+	}
+
+	// ? Lstat, so a symlink planted in its place is not followed
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to check socket directory: %w", err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("socket directory %s is not a directory", dir)
+	}
+	if err := checkPrivateDir(dir, info); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
@@ -0,0 +1,95 @@
+package remote
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSocketPath(t *testing.T) {
+	t.Run("Runtime directory", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("XDG_RUNTIME_DIR", dir)
+
+		path, err := SocketPath("slides.md")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if filepath.Dir(path) != dir {
+			t.Errorf("Expected the socket in %s, got %s", dir, path)
+		}
+	})
+
+	t.Run("Same file, same socket", func(t *testing.T) {
+		t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+		first, _ := SocketPath("slides.md")
+		second, _ := SocketPath("./slides.md")
+		other, _ := SocketPath("other.md")
+		if first != second {
+			t.Errorf("Expected %s and %s to match", first, second)
+		}
+		if first == other {
+			t.Errorf("Expected different files to get different sockets, got %s", first)
+		}
+	})
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	t.Run("Private fallback directory", func(t *testing.T) {
+		tmp := t.TempDir()
+		t.Setenv("XDG_RUNTIME_DIR", "")
+		t.Setenv("TMPDIR", tmp)
+
+		path, err := SocketPath("slides.md")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !strings.HasPrefix(path, tmp) {
+			t.Errorf("Expected the socket under %s, got %s", tmp, path)
+		}
+
+		info, err := os.Stat(filepath.Dir(path))
+		if err != nil {
+			t.Fatalf("Expected the socket directory to exist, got %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o700 {
+			t.Errorf("Expected mode 0700, got %o", perm)
+		}
+	})
+
+	t.Run("Shared fallback directory", func(t *testing.T) {
+		tmp := t.TempDir()
+		t.Setenv("XDG_RUNTIME_DIR", "")
+		t.Setenv("TMPDIR", tmp)
+
+		if err := os.Mkdir(filepath.Join(tmp, userDirName()), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(filepath.Join(tmp, userDirName()), 0o777); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := SocketPath("slides.md"); err == nil {
+			t.Error("Expected an error for a directory other users can write to")
+		}
+	})
+
+	t.Run("Symlinked fallback directory", func(t *testing.T) {
+		tmp := t.TempDir()
+		t.Setenv("XDG_RUNTIME_DIR", "")
+		t.Setenv("TMPDIR", tmp)
+
+		if err := os.Symlink(t.TempDir(), filepath.Join(tmp, userDirName())); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := SocketPath("slides.md"); err == nil {
+			t.Error("Expected an error for a symlink in place of the directory")
+		}
+	})
+}
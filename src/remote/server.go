@@ -0,0 +1,198 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// writeTimeout drops a client that stops reading, such as a suspended
+// console, rather than letting it hold up the others
+const writeTimeout = 2 * time.Second
+
+// * Server accepts presenter connections for a running presentation
+type Server struct {
+	listener net.Listener
+	path     string
+	commands chan Message
+	done     chan struct{}
+	closing  sync.Once
+
+	mu     sync.Mutex
+	conns  map[net.Conn]chan Message
+	state  Message
+	closed bool
+}
+
+func Listen(filePath string) (*Server, error) {
+	path, err := SocketPath(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// ? Refuse to steal the socket from a live presentation, clean up stale ones
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("presentation is already being served at %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+
+	server := &Server{
+		listener: listener,
+		path:     path,
+		commands: make(chan Message, 16),
+		done:     make(chan struct{}),
+		conns:    make(map[net.Conn]chan Message),
+		state:    Message{Type: MessageState},
+	}
+
+	go server.acceptLoop()
+
+	return server, nil
+}
+
+func (s *Server) acceptLoop() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+
+		// * Send the current position so the client starts in sync
+		updates := make(chan Message, 1)
+		s.mu.Lock()
+		// ? A connection accepted while shutting down would never be closed
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = updates
+		updates <- s.state
+		s.mu.Unlock()
+
+		go s.writeLoop(conn, updates)
+		go s.readLoop(conn)
+	}
+}
+
+// writeLoop sends state updates to one client, so a slow client never
+// holds up Broadcast
+func (s *Server) writeLoop(conn net.Conn, updates <-chan Message) {
+	encoder := json.NewEncoder(conn)
+	for state := range updates {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := encoder.Encode(state); err != nil {
+			s.drop(conn)
+			return
+		}
+	}
+}
+
+func (s *Server) readLoop(conn net.Conn) {
+	defer s.drop(conn)
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var msg Message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+
+		if msg.Type != MessageCommand {
+			continue
+		}
+
+		select {
+		case s.commands <- msg:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *Server) drop(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if updates, ok := s.conns[conn]; ok {
+		delete(s.conns, conn)
+		close(updates)
+	}
+	_ = conn.Close()
+}
+
+// Commands delivers navigation commands received from connected clients
+func (s *Server) Commands() <-chan Message {
+	return s.commands
+}
+
+// Broadcast records the current position and queues it for every client
+// without waiting for them. A client still busy with an older state only
+// gets the latest one, as each state replaces the last.
+func (s *Server) Broadcast(state Message) {
+	state.Type = MessageState
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = state
+	for _, updates := range s.conns {
+		// ? Broadcast is the only sender, so once the stale state is taken
+		// out there is room
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- state
+	}
+}
+
+func (s *Server) Path() string {
+	return s.path
+}
+
+func (s *Server) Close() error {
+	var err error
+	s.closing.Do(func() {
+		err = s.shutdown()
+	})
+	return err
+}
+
+func (s *Server) shutdown() error {
+	close(s.done)
+	err := s.listener.Close()
+
+	s.mu.Lock()
+	s.closed = true
+	for conn, updates := range s.conns {
+		close(updates)
+		_ = conn.Close()
+	}
+	s.conns = make(map[net.Conn]chan Message)
+	s.mu.Unlock()
+
+	if removeErr := os.Remove(s.path); removeErr != nil && !os.IsNotExist(removeErr) && err == nil {
+		err = removeErr
+	}
+
+	return err
+}
@@ -0,0 +1,133 @@
+package remote
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func listen(t *testing.T) *Server {
+	t.Helper()
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	server, err := Listen("slides.md")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = server.Close() })
+
+	return server
+}
+
+func dial(t *testing.T) *Client {
+	t.Helper()
+
+	client, err := Dial("slides.md")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func receive(t *testing.T, client *Client) Message {
+	t.Helper()
+
+	received := make(chan Message, 1)
+	failed := make(chan error, 1)
+	go func() {
+		msg, err := client.Receive()
+		if err != nil {
+			failed <- err
+			return
+		}
+		received <- msg
+	}()
+
+	select {
+	case msg := <-received:
+		return msg
+	case err := <-failed:
+		t.Fatalf("Expected a state update, got %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a state update, timed out")
+	}
+	return Message{}
+}
+
+func TestServerRoundTrip(t *testing.T) {
+	server := listen(t)
+	server.Broadcast(Message{Slide: 2, Fragment: 1})
+
+	t.Run("Late join gets the current position", func(t *testing.T) {
+		client := dial(t)
+		if msg := receive(t, client); msg.Slide != 2 || msg.Fragment != 1 {
+			t.Errorf("Expected slide 2 fragment 1, got slide %d fragment %d", msg.Slide, msg.Fragment)
+		}
+	})
+
+	t.Run("Broadcast reaches every client", func(t *testing.T) {
+		first, second := dial(t), dial(t)
+		receive(t, first)
+		receive(t, second)
+
+		server.Broadcast(Message{Slide: 5})
+		for _, client := range []*Client{first, second} {
+			msg := receive(t, client)
+			if msg.Type != MessageState || msg.Slide != 5 {
+				t.Errorf("Expected a state for slide 5, got %+v", msg)
+			}
+		}
+	})
+
+	t.Run("Commands reach the server", func(t *testing.T) {
+		client := dial(t)
+		receive(t, client)
+
+		if err := client.Send(CommandGoTo, 4); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		select {
+		case msg := <-server.Commands():
+			if msg.Command != CommandGoTo || msg.Slide != 4 {
+				t.Errorf("Expected goto 4, got %s %d", msg.Command, msg.Slide)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("Expected a command, timed out")
+		}
+	})
+
+	t.Run("Second server refused", func(t *testing.T) {
+		if _, err := Listen("slides.md"); err == nil {
+			t.Error("Expected an error while the presentation is served")
+		}
+	})
+}
+
+func TestServerClose(t *testing.T) {
+	server := listen(t)
+	client := dial(t)
+	receive(t, client)
+
+	if err := server.Close(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := client.Receive(); err == nil {
+		t.Error("Expected the client to see the presentation end")
+	}
+	if _, err := os.Stat(server.Path()); !os.IsNotExist(err) {
+		t.Errorf("Expected the socket to be removed, got %v", err)
+	}
+	if _, err := Dial("slides.md"); err == nil {
+		t.Error("Expected no connection after close")
+	}
+
+	// ? Broadcast and Close after shutdown are harmless
+	server.Broadcast(Message{Slide: 1})
+	if err := server.Close(); err != nil {
+		t.Errorf("Expected a second close to do nothing, got %v", err)
+	}
+}
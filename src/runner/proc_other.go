@@ -0,0 +1,9 @@
+//go:build !unix
+
+package runner
+
+import "os/exec"
+
+// setProcessGroup leaves the command as it is, where process groups are
+// not available only the command itself is stopped
+func setProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,205 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+// * Event is a chunk of output from a running block, or the end of the run
+type Event struct {
+	Output string
+	Done   bool
+	// Err tells why the run failed, nil when the command exited cleanly
+	Err error
+}
+
+// * Process is a code block running in the background
+type Process struct {
+	events chan Event
+	cancel context.CancelFunc
+}
+
+// * Other names languages go by in fence info strings
+var aliases = map[string]string{
+	"golang":  "go",
+	"py":      "python",
+	"python3": "python",
+	"js":      "javascript",
+	"node":    "javascript",
+	"rb":      "ruby",
+	"shell":   "sh",
+}
+
+// * File extensions some commands need to recognise the code
+var extensions = map[string]string{
+	"go":         ".go",
+	"python":     ".py",
+	"javascript": ".js",
+	"ruby":       ".rb",
+	"bash":       ".sh",
+	"sh":         ".sh",
+}
+
+// ErrCancelled is the error of a run stopped by the presenter
+var ErrCancelled = errors.New("cancelled")
+
+// Language returns the name a fence language is configured under
+func Language(language string) string {
+	language = strings.ToLower(language)
+	if name, ok := aliases[language]; ok {
+		return name
+	}
+	return language
+}
+
+// Command returns the command line that runs a block, with {file} standing
+// for the file holding its code
+func Command(block models.CodeBlock, config models.ExecConfig) (string, error) {
+	command, ok := config.Commands[Language(block.Language)]
+	if !ok || strings.TrimSpace(command) == "" {
+		return "", fmt.Errorf("no command configured to run %s code", block.Language)
+	}
+	return command, nil
+}
+
+// Allowed reports whether the deck at path may run code without asking,
+// because it or a directory holding it is on the allow list
+func Allowed(path string, allow []string) bool {
+	deck, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	for _, entry := range allow {
+		entry, err := filepath.Abs(expandHome(entry))
+		if err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(entry, deck); err == nil && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return false
+}
+
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// Start runs a block in the background from dir, with stdout and stderr
+// streamed together as events. The run is stopped after the configured
+// timeout.
+func Start(block models.CodeBlock, config models.ExecConfig, dir string) (*Process, error) {
+	command, err := Command(block, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// * Write the code where the command can read it
+	tmp, err := os.MkdirTemp("", "slate-run-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create run directory: %w", err)
+	}
+	language := Language(block.Language)
+	ext, ok := extensions[language]
+	if !ok {
+		ext = "." + language
+	}
+	file := filepath.Join(tmp, "main"+ext)
+	if err := os.WriteFile(file, []byte(block.Code+"\n"), 0600); err != nil {
+		_ = os.RemoveAll(tmp)
+		return nil, fmt.Errorf("failed to write code: %w", err)
+	}
+
+	args := strings.Fields(command)
+	if !strings.Contains(command, "{file}") {
+		args = append(args, file)
+	}
+	for i, arg := range args {
+		args[i] = strings.ReplaceAll(arg, "{file}", file)
+	}
+
+	ctx, cancel := runContext(config.Timeout)
+
+	p := &Process{
+		events: make(chan Event, 64),
+		cancel: cancel,
+	}
+
+	// #nosec G204 -- running the deck's code is the point, after the presenter agreed
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	// ? One writer for both streams keeps their output in order
+	out := &eventWriter{events: p.events}
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.WaitDelay = time.Second
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		cancel()
+		_ = os.RemoveAll(tmp)
+		return nil, fmt.Errorf("failed to run %s: %w", args[0], err)
+	}
+
+	go func() {
+		defer func() { _ = os.RemoveAll(tmp) }()
+		defer cancel()
+
+		err := cmd.Wait()
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			err = fmt.Errorf("timed out after %s", config.Timeout)
+		case errors.Is(ctx.Err(), context.Canceled):
+			err = ErrCancelled
+		}
+
+		p.events <- Event{Done: true, Err: err}
+		close(p.events)
+	}()
+
+	return p, nil
+}
+
+// runContext stops a run after timeout. Loaded configs always have one, as
+// Merge only takes timeouts above 0 over the default, so only an ExecConfig
+// built without a timeout runs until cancelled.
+func runContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(context.Background(), timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// Events returns the output of the run, ending with a Done event
+func (p *Process) Events() <-chan Event {
+	return p.events
+}
+
+// Cancel stops the run
+func (p *Process) Cancel() {
+	p.cancel()
+}
+
+// * eventWriter turns command output into events
+type eventWriter struct {
+	events chan<- Event
+}
+
+func (w *eventWriter) Write(b []byte) (int, error) {
+	w.events <- Event{Output: string(b)}
+	return len(b), nil
+}
@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+func run(t *testing.T, block models.CodeBlock, config models.ExecConfig) (string, error) {
+	t.Helper()
+
+	process, err := Start(block, config, t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var output strings.Builder
+	for event := range process.Events() {
+		if event.Done {
+			return output.String(), event.Err
+		}
+		output.WriteString(event.Output)
+	}
+	t.Fatal("Expected a done event")
+	return "", nil
+}
+
+func TestStart(t *testing.T) {
+	config := models.NewDefaultConfig().Exec
+	block := models.CodeBlock{Language: "shell", Code: "echo out\necho err >&2\nexit 3"}
+
+	output, err := run(t, block, config)
+	if output != "out\nerr\n" {
+		t.Errorf("Expected stdout and stderr in order, got %q", output)
+	}
+
+	var exitErr interface{ ExitCode() int }
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("Expected exit status 3, got %v", err)
+	}
+}
+
+func TestStartTimeout(t *testing.T) {
+	config := models.ExecConfig{
+		Timeout:  100 * time.Millisecond,
+		Commands: map[string]string{"sh": "sh {file}"},
+	}
+
+	_, err := run(t, models.CodeBlock{Language: "sh", Code: "sleep 5"}, config)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("Expected a timeout, got %v", err)
+	}
+}
+
+func TestStartUnknownLanguage(t *testing.T) {
+	_, err := Start(models.CodeBlock{Language: "cobol"}, models.NewDefaultConfig().Exec, t.TempDir())
+	if err == nil {
+		t.Error("Expected an error for a language without a command")
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	dir := t.TempDir()
+	deck := filepath.Join(dir, "talks", "deck.md")
+	if err := os.MkdirAll(filepath.Dir(deck), 0750); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		allow    []string
+		expected bool
+	}{
+		{"Empty list", nil, false},
+		{"Deck itself", []string{deck}, true},
+		{"Parent directory", []string{dir}, true},
+		{"Sibling directory", []string{filepath.Join(dir, "other")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allowed(deck, tt.allow); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"unicode"
+
+	"github.com/Kosha-Nirman/slate/src/display"
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+// * Query describes what to look for across the slides
+type Query struct {
+	Text string
+	// Regex treats Text as a regular expression instead of a literal
+	Regex bool
+}
+
+// Compile builds the pattern for a query. Matching ignores case unless the
+// query contains an upper case letter.
+func (q Query) Compile() (*regexp.Regexp, error) {
+	if q.Text == "" {
+		return nil, fmt.Errorf("empty search")
+	}
+
+	pattern := q.Text
+	if !q.Regex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+
+	if !hasUpper(q.Text) {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	return re, nil
+}
+
+func hasUpper(text string) bool {
+	for _, r := range text {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the indexes of the slides whose content matches, ignoring
+// metadata comments
+func Find(presentation *models.Presentation, re *regexp.Regexp) []int {
+	matches := make([]int, 0)
+
+	for i, slide := range presentation.Slides {
+		if re.MatchString(display.StripMetadataComments(slide.RawContent)) {
+			matches = append(matches, i)
+		}
+	}
+
+	return matches
+}
+
+// Fragment returns the first fragment of a slide that reveals a match, or
+// the last fragment when none does on its own
+func Fragment(slide *models.Slide, re *regexp.Regexp) int {
+	for fragment := range slide.FragmentCount() {
+		if re.MatchString(display.StripMetadataComments(slide.FragmentContent(fragment))) {
+			return fragment
+		}
+	}
+	return slide.FragmentCount() - 1
+}
+
+// Next returns the first match after current, wrapping around to the
+// start, or -1 when there are no matches
+func Next(matches []int, current int) int {
+	if len(matches) == 0 {
+		return -1
+	}
+
+	for _, index := range matches {
+		if index > current {
+			return index
+		}
+	}
+	return matches[0]
+}
+
+// Previous returns the last match before current, wrapping around to the
+// end, or -1 when there are no matches
+func Previous(matches []int, current int) int {
+	if len(matches) == 0 {
+		return -1
+	}
+
+	for i := len(matches) - 1; i >= 0; i-- {
+		if matches[i] < current {
+			return matches[i]
+		}
+	}
+	return matches[len(matches)-1]
+}
@@ -0,0 +1,94 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+func TestQueryCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   Query
+		text    string
+		matches bool
+	}{
+		{"Lower case ignores case", Query{Text: "slate"}, "Welcome to Slate", true},
+		{"Upper case matches case", Query{Text: "Slate"}, "welcome to slate", false},
+		{"Literal escapes regex", Query{Text: "a.b"}, "axb", false},
+		{"Regex mode", Query{Text: "a.b", Regex: true}, "axb", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := tt.query.Compile()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if re.MatchString(tt.text) != tt.matches {
+				t.Errorf("Expected match %v for %q in %q", tt.matches, tt.query.Text, tt.text)
+			}
+		})
+	}
+}
+
+func TestQueryCompileInvalidRegex(t *testing.T) {
+	if _, err := (Query{Text: "(", Regex: true}).Compile(); err == nil {
+		t.Error("Expected error for invalid regex")
+	}
+}
+
+func TestFindIgnoresMetadata(t *testing.T) {
+	presentation := &models.Presentation{
+		Slides: []*models.Slide{
+			models.NewSlide(0, "# Intro\n<!-- @notes: mention the roadmap -->"),
+			models.NewSlide(1, "# Roadmap"),
+		},
+	}
+
+	re, _ := Query{Text: "roadmap"}.Compile()
+	matches := Find(presentation, re)
+
+	if len(matches) != 1 || matches[0] != 1 {
+		t.Errorf("Expected matches [1], got %v", matches)
+	}
+}
+
+func TestNextAndPreviousWrap(t *testing.T) {
+	matches := []int{2, 5, 9}
+
+	if got := Next(matches, 9); got != 2 {
+		t.Errorf("Expected next to wrap to 2, got %d", got)
+	}
+	if got := Previous(matches, 2); got != 9 {
+		t.Errorf("Expected previous to wrap to 9, got %d", got)
+	}
+	if got := Next(nil, 0); got != -1 {
+		t.Errorf("Expected -1 without matches, got %d", got)
+	}
+}
+
+func TestFragment(t *testing.T) {
+	slide := models.NewSlide(0, "# Plan\n\n- Intro\n- Roadmap\n- Demo")
+	slide.Fragments = []string{"# Plan\n", "- Intro", "- Roadmap\n<!-- @notes: budget -->", "- Demo"}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{"Shown from the start", "plan", 0},
+		{"Revealed later", "roadmap", 2},
+		{"Last fragment", "demo", 3},
+		{"Only in notes", "budget", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, _ := Query{Text: tt.query}.Compile()
+			if got := Fragment(slide, re); got != tt.expected {
+				t.Errorf("Expected fragment %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
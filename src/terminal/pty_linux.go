@@ -0,0 +1,81 @@
+//go:build linux
+
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Supported reports whether terminal slides can start on this system
+const Supported = true
+
+// startPTY runs the command on a new pseudo-terminal and returns its
+// controlling side
+func startPTY(cmd *exec.Cmd, width, height int) (*os.File, error) {
+	pty, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pseudo-terminal: %w", err)
+	}
+
+	tty, err := openTTY(pty)
+	if err != nil {
+		_ = pty.Close()
+		return nil, err
+	}
+	// ? The command holds its own copy of the terminal once started
+	defer func() { _ = tty.Close() }()
+
+	if err := setSize(pty, width, height); err != nil {
+		_ = pty.Close()
+		return nil, err
+	}
+
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = tty, tty, tty
+	// ? A session of its own makes the terminal the command's controlling one
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+
+	if err := cmd.Start(); err != nil {
+		_ = pty.Close()
+		return nil, fmt.Errorf("failed to start %s: %w", cmd.Path, err)
+	}
+
+	return pty, nil
+}
+
+// openTTY unlocks and opens the program side of a pseudo-terminal
+func openTTY(pty *os.File) (*os.File, error) {
+	fd := int(pty.Fd())
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		return nil, fmt.Errorf("failed to unlock pseudo-terminal: %w", err)
+	}
+
+	n, err := unix.IoctlGetInt(fd, unix.TIOCGPTN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pseudo-terminal: %w", err)
+	}
+
+	tty, err := os.OpenFile("/dev/pts/"+strconv.Itoa(n), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pseudo-terminal: %w", err)
+	}
+	return tty, nil
+}
+
+func setSize(pty *os.File, width, height int) error {
+	size := &unix.Winsize{Col: uint16(max(width, 1)), Row: uint16(max(height, 1))}
+	if err := unix.IoctlSetWinsize(int(pty.Fd()), unix.TIOCSWINSZ, size); err != nil {
+		return fmt.Errorf("failed to resize pseudo-terminal: %w", err)
+	}
+	return nil
+}
+
+// kill stops the command along with everything started from it
+func kill(cmd *exec.Cmd) {
+	_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,26 @@
+//go:build !linux
+
+package terminal
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// Supported reports whether terminal slides can start on this system
+const Supported = false
+
+var errUnsupported = errors.New("terminal slides are only supported on Linux")
+
+func startPTY(cmd *exec.Cmd, width, height int) (*os.File, error) {
+	return nil, errUnsupported
+}
+
+func setSize(pty *os.File, width, height int) error {
+	return errUnsupported
+}
+
+func kill(cmd *exec.Cmd) {
+	_ = cmd.Process.Kill()
+}
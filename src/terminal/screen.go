@@ -0,0 +1,456 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// * cell is one character on the screen with the SGR parameters styling it
+type cell struct {
+	r     rune
+	style string
+}
+
+// * Marks the second column of a wide character
+const wideTail = -1
+
+// * Screen keeps the characters a program draws on a terminal. It follows
+// the escape sequences shells and common command line tools use, which is
+// enough for demos without being a full terminal emulator.
+type Screen struct {
+	width, height int
+	cells         [][]cell
+	x, y          int
+	// style holds the SGR parameters new characters are drawn with
+	style string
+	// top and bottom bound the region that scrolls
+	top, bottom int
+	// wrapNext defers wrapping until a character is printed past the edge
+	wrapNext     bool
+	savedX       int
+	savedY       int
+	cursorHidden bool
+	// main keeps the normal screen while a program uses the alternate one
+	main [][]cell
+
+	parser *ansi.Parser
+	// reply answers queries such as the cursor position
+	reply func([]byte)
+}
+
+// NewScreen returns an empty screen. Answers to queries from the program
+// are passed to reply.
+func NewScreen(width, height int, reply func([]byte)) *Screen {
+	s := &Screen{reply: reply}
+	s.Resize(width, height)
+
+	s.parser = ansi.NewParser()
+	s.parser.SetHandler(ansi.Handler{
+		Print:     s.print,
+		Execute:   s.execute,
+		HandleCsi: s.handleCsi,
+		HandleEsc: s.handleEsc,
+	})
+
+	return s
+}
+
+// Write feeds program output to the screen
+func (s *Screen) Write(b []byte) (int, error) {
+	for _, c := range b {
+		s.parser.Advance(c)
+	}
+	return len(b), nil
+}
+
+// Resize changes the size of the screen, keeping what fits
+func (s *Screen) Resize(width, height int) {
+	width, height = max(width, 1), max(height, 1)
+
+	cells := blankLines(width, height)
+	// ? Keep the bottom lines, where the prompt usually is
+	shift := max(s.y+1-height, 0)
+	for y := range min(height, len(s.cells)-shift) {
+		copy(cells[y], s.cells[y+shift])
+	}
+
+	s.width, s.height, s.cells = width, height, cells
+	s.x = min(s.x, width-1)
+	s.y = min(max(s.y-shift, 0), height-1)
+	s.top, s.bottom = 0, height-1
+	s.main = nil
+	s.wrapNext = false
+}
+
+// View renders the screen, showing the cursor in reverse video when asked
+func (s *Screen) View(cursor bool) string {
+	lines := make([]string, s.height)
+	for y, row := range s.cells {
+		var line strings.Builder
+		style := ""
+		for x, c := range row {
+			if c.r == wideTail {
+				continue
+			}
+
+			want := c.style
+			if cursor && !s.cursorHidden && x == s.x && y == s.y {
+				want += ";7"
+			}
+			if want != style {
+				line.WriteString("\x1b[0" + want + "m")
+				style = want
+			}
+
+			if c.r == 0 {
+				line.WriteByte(' ')
+			} else {
+				line.WriteRune(c.r)
+			}
+		}
+		if style != "" {
+			line.WriteString("\x1b[0m")
+		}
+		lines[y] = line.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func blankLines(width, height int) [][]cell {
+	lines := make([][]cell, height)
+	for i := range lines {
+		lines[i] = make([]cell, width)
+	}
+	return lines
+}
+
+func (s *Screen) print(r rune) {
+	width := ansi.StringWidth(string(r))
+	if width == 0 {
+		return
+	}
+
+	if s.wrapNext || s.x+width > s.width {
+		s.x = 0
+		s.lineFeed()
+	}
+	s.wrapNext = false
+
+	s.cells[s.y][s.x] = cell{r: r, style: s.style}
+	if width == 2 && s.x+1 < s.width {
+		s.cells[s.y][s.x+1] = cell{r: wideTail, style: s.style}
+	}
+
+	s.x += width
+	if s.x >= s.width {
+		s.x = s.width - 1
+		s.wrapNext = true
+	}
+}
+
+func (s *Screen) execute(b byte) {
+	switch b {
+	case '\r':
+		s.x = 0
+	case '\n', '\v', '\f':
+		s.lineFeed()
+	case '\b':
+		s.x = max(s.x-1, 0)
+	case '\t':
+		s.x = min((s.x/8+1)*8, s.width-1)
+	default:
+		return
+	}
+	s.wrapNext = false
+}
+
+// lineFeed moves down a line, scrolling at the bottom of the region
+func (s *Screen) lineFeed() {
+	if s.y == s.bottom {
+		s.scrollUp(1)
+	} else if s.y < s.height-1 {
+		s.y++
+	}
+}
+
+func (s *Screen) reverseIndex() {
+	if s.y == s.top {
+		s.scrollDown(1)
+	} else if s.y > 0 {
+		s.y--
+	}
+}
+
+// scrollUp moves the lines of the scroll region up, adding blank lines at
+// its bottom
+func (s *Screen) scrollUp(n int) {
+	region := s.cells[s.top : s.bottom+1]
+	n = min(n, len(region))
+	copy(region, region[n:])
+	for i := len(region) - n; i < len(region); i++ {
+		region[i] = make([]cell, s.width)
+	}
+}
+
+func (s *Screen) scrollDown(n int) {
+	region := s.cells[s.top : s.bottom+1]
+	n = min(n, len(region))
+	copy(region[n:], region)
+	for i := range n {
+		region[i] = make([]cell, s.width)
+	}
+}
+
+func (s *Screen) handleEsc(cmd ansi.Cmd) {
+	if cmd.Intermediate() != 0 {
+		return
+	}
+
+	switch cmd.Final() {
+	case '7':
+		s.savedX, s.savedY = s.x, s.y
+	case '8':
+		s.moveTo(s.savedX, s.savedY)
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.x = 0
+		s.lineFeed()
+	case 'M':
+		s.reverseIndex()
+	case 'c':
+		s.style = ""
+		s.cursorHidden = false
+		s.Resize(s.width, s.height)
+		s.erase(0, 0, s.width, s.height)
+		s.moveTo(0, 0)
+	}
+}
+
+func (s *Screen) handleCsi(cmd ansi.Cmd, params ansi.Params) {
+	param := func(i, def int) int {
+		n, _, _ := params.Param(i, def)
+		if n == 0 && def > 0 {
+			return def
+		}
+		return n
+	}
+	n := param(0, 1)
+
+	if cmd.Prefix() == '?' {
+		s.setPrivateMode(cmd.Final(), params)
+		return
+	}
+	if cmd.Prefix() != 0 || cmd.Intermediate() != 0 {
+		return
+	}
+
+	switch cmd.Final() {
+	case 'A':
+		s.moveTo(s.x, max(s.y-n, s.top))
+	case 'B', 'e':
+		s.moveTo(s.x, min(s.y+n, s.bottom))
+	case 'C', 'a':
+		s.moveTo(s.x+n, s.y)
+	case 'D':
+		s.moveTo(s.x-n, s.y)
+	case 'E':
+		s.moveTo(0, min(s.y+n, s.bottom))
+	case 'F':
+		s.moveTo(0, max(s.y-n, s.top))
+	case 'G', '`':
+		s.moveTo(n-1, s.y)
+	case 'd':
+		s.moveTo(s.x, n-1)
+	case 'H', 'f':
+		s.moveTo(param(1, 1)-1, n-1)
+	case 'J':
+		s.eraseDisplay(param(0, 0))
+	case 'K':
+		s.eraseLine(param(0, 0))
+	case 'L':
+		if s.y >= s.top && s.y <= s.bottom {
+			top := s.top
+			s.top = s.y
+			s.scrollDown(n)
+			s.top = top
+		}
+	case 'M':
+		if s.y >= s.top && s.y <= s.bottom {
+			top := s.top
+			s.top = s.y
+			s.scrollUp(n)
+			s.top = top
+		}
+	case '@':
+		row := s.cells[s.y]
+		n = min(n, s.width-s.x)
+		copy(row[s.x+n:], row[s.x:])
+		s.erase(s.x, s.y, s.x+n, s.y+1)
+	case 'P':
+		row := s.cells[s.y]
+		n = min(n, s.width-s.x)
+		copy(row[s.x:], row[s.x+n:])
+		s.erase(s.width-n, s.y, s.width, s.y+1)
+	case 'X':
+		s.erase(s.x, s.y, min(s.x+n, s.width), s.y+1)
+	case 'S':
+		s.scrollUp(n)
+	case 'T':
+		s.scrollDown(n)
+	case 'm':
+		s.setStyle(params)
+	case 'r':
+		top, bottom := param(0, 1)-1, param(1, s.height)-1
+		if top < bottom && bottom < s.height {
+			s.top, s.bottom = top, bottom
+			s.moveTo(0, 0)
+		}
+	case 's':
+		s.savedX, s.savedY = s.x, s.y
+	case 'u':
+		s.moveTo(s.savedX, s.savedY)
+	case 'n':
+		if param(0, 0) == 6 {
+			s.answer(fmt.Sprintf("\x1b[%d;%dR", s.y+1, s.x+1))
+		}
+	case 'c':
+		// ? Claim to be a VT100 with advanced video
+		s.answer("\x1b[?1;2c")
+	}
+}
+
+func (s *Screen) setPrivateMode(final byte, params ansi.Params) {
+	if final != 'h' && final != 'l' {
+		return
+	}
+	on := final == 'h'
+
+	params.ForEach(0, func(_, mode int, _ bool) {
+		switch mode {
+		case 25:
+			s.cursorHidden = !on
+		case 47, 1047, 1049:
+			s.setAlternateScreen(on)
+		}
+	})
+}
+
+// setAlternateScreen switches to a blank screen for full screen programs
+// and back to what was on screen before
+func (s *Screen) setAlternateScreen(on bool) {
+	if on == (s.main != nil) {
+		return
+	}
+
+	if on {
+		s.savedX, s.savedY = s.x, s.y
+		s.main = s.cells
+		s.cells = blankLines(s.width, s.height)
+		return
+	}
+
+	s.cells, s.main = s.main, nil
+	s.moveTo(s.savedX, s.savedY)
+}
+
+// setStyle adds SGR parameters to the current style, starting over when
+// they reset it
+func (s *Screen) setStyle(params ansi.Params) {
+	if len(params) == 0 {
+		s.style = ""
+		return
+	}
+
+	var style strings.Builder
+	for i := 0; i < len(params); i++ {
+		n := params[i].Param(0)
+		if n == 0 {
+			// ? A reset drops everything before it
+			style.Reset()
+			s.style = ""
+			continue
+		}
+
+		// ? Extended colors carry their values in the following parameters,
+		// either joined by colons or as 5;n or 2;r;g;b
+		end := i
+		switch {
+		case params[i].HasMore():
+			for end < len(params)-1 && params[end].HasMore() {
+				end++
+			}
+		case n == 38 || n == 48 || n == 58:
+			if kind, _, _ := params.Param(i+1, 0); kind == 5 {
+				end = i + 2
+			} else if kind == 2 {
+				end = i + 4
+			}
+		}
+		end = min(end, len(params)-1)
+
+		// ? Keep colons where the program used them
+		style.WriteByte(';')
+		for j := i; j <= end; j++ {
+			style.WriteString(strconv.Itoa(params[j].Param(0)))
+			if j < end {
+				if params[j].HasMore() {
+					style.WriteByte(':')
+				} else {
+					style.WriteByte(';')
+				}
+			}
+		}
+		i = end
+	}
+
+	s.style += style.String()
+}
+
+func (s *Screen) moveTo(x, y int) {
+	s.x = min(max(x, 0), s.width-1)
+	s.y = min(max(y, 0), s.height-1)
+	s.wrapNext = false
+}
+
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+		s.erase(0, s.y+1, s.width, s.height)
+	case 1:
+		s.eraseLine(1)
+		s.erase(0, 0, s.width, s.y)
+	case 2, 3:
+		s.erase(0, 0, s.width, s.height)
+	}
+}
+
+func (s *Screen) eraseLine(mode int) {
+	switch mode {
+	case 0:
+		s.erase(s.x, s.y, s.width, s.y+1)
+	case 1:
+		s.erase(0, s.y, s.x+1, s.y+1)
+	case 2:
+		s.erase(0, s.y, s.width, s.y+1)
+	}
+}
+
+// erase blanks the cells in columns [x1, x2) of rows [y1, y2)
+func (s *Screen) erase(x1, y1, x2, y2 int) {
+	for y := max(y1, 0); y < min(y2, s.height); y++ {
+		for x := max(x1, 0); x < min(x2, s.width); x++ {
+			s.cells[y][x] = cell{}
+		}
+	}
+}
+
+func (s *Screen) answer(reply string) {
+	if s.reply != nil {
+		s.reply([]byte(reply))
+	}
+}
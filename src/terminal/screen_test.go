@@ -0,0 +1,93 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// text returns the screen without styling and with trailing spaces removed
+func text(s *Screen) string {
+	lines := strings.Split(ansi.Strip(s.View(false)), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestScreenWrite(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Lines", "one\r\ntwo", "one\ntwo\n"},
+		{"Wrapping", "abcdefg", "abcde\nfg\n"},
+		{"Scrolling", "1\r\n2\r\n3\r\n4", "2\n3\n4"},
+		{"Carriage return", "hello\rj", "jello\n\n"},
+		{"Backspace and erase line", "help\b\x1b[K", "hel\n\n"},
+		{"Cursor position", "\x1b[2;3Hx", "\n  x\n"},
+		{"Erase display", "abc\r\ndef\x1b[2J", "\n\n"},
+		{"Delete characters", "abcde\x1b[1;2H\x1b[2P", "ade\n\n"},
+		{"Insert line", "one\r\ntwo\x1b[1;1H\x1b[L", "\none\ntwo"},
+		{"Wide characters", "日本語", "日本\n語\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScreen(5, 3, nil)
+			_, _ = s.Write([]byte(tt.input))
+			if got := text(s); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestScreenStyle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Color", "\x1b[31mx", ";31"},
+		{"Added attribute", "\x1b[31m\x1b[1mx", ";31;1"},
+		{"Reset", "\x1b[31m\x1b[0;4mx", ";4"},
+		{"Indexed black is not a reset", "\x1b[38;5;0mx", ";38;5;0"},
+		{"Colon true color", "\x1b[38:2:1:2:3mx", ";38:2:1:2:3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScreen(5, 1, nil)
+			_, _ = s.Write([]byte(tt.input))
+			if got := s.cells[0][0].style; got != tt.expected {
+				t.Errorf("Expected style %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestScreenAlternate(t *testing.T) {
+	s := NewScreen(5, 2, nil)
+	_, _ = s.Write([]byte("shell\x1b[?1049h\x1b[Hfull"))
+	if got := text(s); got != "full\n" {
+		t.Errorf("Expected the alternate screen, got %q", got)
+	}
+
+	_, _ = s.Write([]byte("\x1b[?1049l"))
+	if got := text(s); got != "shell\n" {
+		t.Errorf("Expected the shell back, got %q", got)
+	}
+}
+
+func TestScreenReplies(t *testing.T) {
+	var replies []string
+	s := NewScreen(10, 5, func(b []byte) { replies = append(replies, string(b)) })
+
+	_, _ = s.Write([]byte("\x1b[3;4H\x1b[6n"))
+	if len(replies) != 1 || replies[0] != "\x1b[3;4R" {
+		t.Errorf("Expected cursor position report, got %q", replies)
+	}
+}
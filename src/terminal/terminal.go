@@ -0,0 +1,125 @@
+package terminal
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// * Terminal is an interactive program running on a pseudo-terminal, with
+// the screen it draws
+type Terminal struct {
+	mu     sync.Mutex
+	screen *Screen
+	pty    *os.File
+	cmd    *exec.Cmd
+	// updates signals new output and is closed once the program exits
+	updates chan struct{}
+	closed  bool
+}
+
+// Start runs command, or the user's shell when it is empty, from dir on a
+// terminal of the given size
+func Start(command, dir string, width, height int) (*Terminal, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		args = []string{defaultShell()}
+	}
+
+	// #nosec G204 -- the presenter agreed to run the deck's terminal
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "TERM=xterm-256color")
+
+	t := &Terminal{
+		cmd:     cmd,
+		updates: make(chan struct{}, 1),
+	}
+	t.screen = NewScreen(width, height, t.Write)
+
+	pty, err := startPTY(cmd, width, height)
+	if err != nil {
+		return nil, err
+	}
+	t.pty = pty
+
+	go t.read()
+
+	return t, nil
+}
+
+func defaultShell() string {
+	if shell := os.Getenv("SHELL"); shell != "" {
+		return shell
+	}
+	return "/bin/sh"
+}
+
+// read feeds the program's output to the screen until it exits
+func (t *Terminal) read() {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := t.pty.Read(buf)
+		if n > 0 {
+			t.mu.Lock()
+			_, _ = t.screen.Write(buf[:n])
+			t.mu.Unlock()
+
+			select {
+			case t.updates <- struct{}{}:
+			default:
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	_ = t.cmd.Wait()
+	close(t.updates)
+}
+
+// Updates signals when the screen changed, and is closed once the program
+// has exited
+func (t *Terminal) Updates() <-chan struct{} {
+	return t.updates
+}
+
+// Write sends input such as key presses to the program
+func (t *Terminal) Write(b []byte) {
+	_, _ = t.pty.Write(b)
+}
+
+// Resize changes the size of the terminal, which the program is told about
+func (t *Terminal) Resize(width, height int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if width == t.screen.width && height == t.screen.height {
+		return
+	}
+	t.screen.Resize(width, height)
+	_ = setSize(t.pty, width, height)
+}
+
+// View renders the screen, with the cursor when the terminal has focus
+func (t *Terminal) View(focused bool) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.screen.View(focused)
+}
+
+// Close stops the program and everything it started
+func (t *Terminal) Close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return
+	}
+	t.closed = true
+
+	kill(t.cmd)
+	_ = t.pty.Close()
+}
@@ -0,0 +1,125 @@
+package theme
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// * The 16 standard ANSI colors in the classic Windows console palette,
+// * which matches the CSS basic colors. Terminals differ here, so contrast
+// * judged on them is an estimate.
+var ansiPalette = [16]string{
+	"#000000", "#800000", "#008000", "#808000", "#000080", "#800080", "#008080", "#c0c0c0",
+	"#808080", "#ff0000", "#00ff00", "#ffff00", "#0000ff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// * Color names accepted in slide metadata, mapped to ANSI indexes
+var namedColors = map[string]int{
+	"black": 0, "red": 1, "green": 2, "yellow": 3, "blue": 4, "magenta": 5, "cyan": 6, "white": 7,
+	"gray": 8, "grey": 8, "brightred": 9, "brightgreen": 10, "brightyellow": 11, "brightblue": 12,
+	"brightmagenta": 13, "brightcyan": 14, "brightwhite": 15,
+	"orange": 208, "purple": 93, "pink": 212, "navy": 18, "teal": 30, "maroon": 88, "olive": 100,
+	"silver": 250, "darkgray": 238, "darkgrey": 238, "lightgray": 252, "lightgrey": 252,
+}
+
+// MinContrast is the lowest contrast ratio at which text stays readable,
+// the WCAG threshold for large text
+const MinContrast = 3.0
+
+var hexColorRegex = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ParseColor reads a color written as a name ("navy", "bright-red"), an
+// ANSI 256 index ("63") or hex ("#ffaa00", "#fa0")
+func ParseColor(value string) (lipgloss.Color, error) {
+	value = strings.TrimSpace(value)
+
+	if hexColorRegex.MatchString(value) {
+		return lipgloss.Color(expandHex(value)), nil
+	}
+
+	if index, err := strconv.Atoi(value); err == nil {
+		if index < 0 || index > 255 {
+			return "", fmt.Errorf("color index %d out of range (0-255)", index)
+		}
+		return lipgloss.Color(value), nil
+	}
+
+	name := strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(value))
+	if index, ok := namedColors[name]; ok {
+		return lipgloss.Color(strconv.Itoa(index)), nil
+	}
+
+	return "", fmt.Errorf("unknown color %q", value)
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors, from 1
+// for identical colors up to 21 for black on white
+func ContrastRatio(a, b lipgloss.Color) float64 {
+	la, lb := luminance(Hex(a)), luminance(Hex(b))
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+func luminance(hex string) float64 {
+	var r, g, b int
+	if _, err := fmt.Sscanf(strings.TrimPrefix(hex, "#"), "%02x%02x%02x", &r, &g, &b); err != nil {
+		return 0
+	}
+
+	channel := func(value int) float64 {
+		c := float64(value) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+
+	return 0.2126*channel(r) + 0.7152*channel(g) + 0.0722*channel(b)
+}
+
+// Hex converts a lipgloss color (hex or ANSI 256 index) to a #rrggbb string
+// for targets such as HTML that do not understand terminal palettes
+func Hex(c lipgloss.Color) string {
+	value := strings.TrimSpace(string(c))
+
+	if strings.HasPrefix(value, "#") {
+		return expandHex(value)
+	}
+
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 0 || index > 255 {
+		return ""
+	}
+
+	switch {
+	case index < 16:
+		return ansiPalette[index]
+	case index < 232:
+		// * 6x6x6 color cube
+		index -= 16
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		return fmt.Sprintf("#%02x%02x%02x", levels[index/36], levels[(index/6)%6], levels[index%6])
+	default:
+		// * Grayscale ramp
+		level := 8 + (index-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", level, level, level)
+	}
+}
+
+func expandHex(value string) string {
+	value = strings.ToLower(value)
+
+	// ? Expand #rgb shorthand
+	if len(value) == 4 {
+		return "#" + strings.Repeat(value[1:2], 2) + strings.Repeat(value[2:3], 2) + strings.Repeat(value[3:4], 2)
+	}
+
+	return value
+}
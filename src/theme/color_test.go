@@ -0,0 +1,58 @@
+package theme
+
+import (
+	"math"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     lipgloss.Color
+		expected float64
+	}{
+		{"Black on white", "#000000", "#ffffff", 21},
+		{"Order does not matter", "#ffffff", "#000000", 21},
+		{"Same color", "#abc", "#aabbcc", 1},
+		{"Gray on white", "#777777", "#ffffff", 4.48},
+		{"Red on white", "#ff0000", "#ffffff", 4.00},
+		{"Blue on black", "#0000ff", "#000000", 2.44},
+		{"ANSI indexes", "0", "15", 21},
+		{"Grayscale ramp", "252", "0", 13.62},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContrastRatio(tt.a, tt.b); math.Abs(got-tt.expected) > 0.01 {
+				t.Errorf("Expected %.2f, got %.2f", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHex(t *testing.T) {
+	tests := []struct {
+		color    lipgloss.Color
+		expected string
+	}{
+		{"#FA0", "#ffaa00"},
+		{"#123456", "#123456"},
+		{"1", "#800000"},
+		{"15", "#ffffff"},
+		{"63", "#5f5fff"},
+		{"232", "#080808"},
+		{"255", "#eeeeee"},
+		{"256", ""},
+		{"red", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.color), func(t *testing.T) {
+			if got := Hex(tt.color); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package theme
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultPalette is used when the config and deck pick no palette
+const DefaultPalette = "default"
+
+// * Palette is a named set of colors for the UI chrome, with one variant
+// for dark and one for light terminals
+type Palette struct {
+	Name        string
+	Description string
+	Dark        ColorScheme
+	Light       ColorScheme
+}
+
+// Palettes lists the palettes bundled with slate, in the order they are
+// shown and switched through
+var Palettes = []Palette{
+	{
+		Name:        DefaultPalette,
+		Description: "purple and pink accents",
+		Dark: ColorScheme{
+			Primary:     lipgloss.Color("63"),  // Purple
+			Secondary:   lipgloss.Color("99"),  // Light purple
+			Background:  lipgloss.Color("235"), // Dark gray
+			Foreground:  lipgloss.Color("255"), // White
+			Accent:      lipgloss.Color("212"), // Pink
+			Muted:       lipgloss.Color("241"), // Gray
+			Error:       lipgloss.Color("196"), // Red
+			Success:     lipgloss.Color("46"),  // Green
+			Warning:     lipgloss.Color("214"), // Orange
+			Border:      lipgloss.Color("240"), // Dark border
+			ProgressBar: lipgloss.Color("63"),  // Purple
+		},
+		Light: ColorScheme{
+			Primary:     lipgloss.Color("63"),  // Purple
+			Secondary:   lipgloss.Color("99"),  // Light purple
+			Background:  lipgloss.Color("255"), // White
+			Foreground:  lipgloss.Color("235"), // Dark gray
+			Accent:      lipgloss.Color("212"), // Pink
+			Muted:       lipgloss.Color("246"), // Light gray
+			Error:       lipgloss.Color("160"), // Dark red
+			Success:     lipgloss.Color("28"),  // Dark green
+			Warning:     lipgloss.Color("166"), // Dark orange
+			Border:      lipgloss.Color("246"), // Light border
+			ProgressBar: lipgloss.Color("63"),  // Purple
+		},
+	},
+	{
+		Name:        "ocean",
+		Description: "blues and teals",
+		Dark: ColorScheme{
+			Primary:     lipgloss.Color("39"),  // Sky blue
+			Secondary:   lipgloss.Color("75"),  // Light blue
+			Background:  lipgloss.Color("17"),  // Navy
+			Foreground:  lipgloss.Color("255"), // White
+			Accent:      lipgloss.Color("44"),  // Teal
+			Muted:       lipgloss.Color("67"),  // Slate blue
+			Error:       lipgloss.Color("203"), // Coral
+			Success:     lipgloss.Color("43"),  // Sea green
+			Warning:     lipgloss.Color("221"), // Sand
+			Border:      lipgloss.Color("24"),  // Deep blue
+			ProgressBar: lipgloss.Color("39"),  // Sky blue
+		},
+		Light: ColorScheme{
+			Primary:     lipgloss.Color("25"),  // Blue
+			Secondary:   lipgloss.Color("31"),  // Steel blue
+			Background:  lipgloss.Color("255"), // White
+			Foreground:  lipgloss.Color("17"),  // Navy
+			Accent:      lipgloss.Color("30"),  // Teal
+			Muted:       lipgloss.Color("66"),  // Gray blue
+			Error:       lipgloss.Color("160"), // Dark red
+			Success:     lipgloss.Color("29"),  // Dark sea green
+			Warning:     lipgloss.Color("136"), // Dark sand
+			Border:      lipgloss.Color("110"), // Light steel blue
+			ProgressBar: lipgloss.Color("25"),  // Blue
+		},
+	},
+	{
+		Name:        "forest",
+		Description: "greens and browns",
+		Dark: ColorScheme{
+			Primary:     lipgloss.Color("71"),  // Leaf green
+			Secondary:   lipgloss.Color("107"), // Olive
+			Background:  lipgloss.Color("234"), // Near black
+			Foreground:  lipgloss.Color("254"), // Off white
+			Accent:      lipgloss.Color("179"), // Tan
+			Muted:       lipgloss.Color("101"), // Moss
+			Error:       lipgloss.Color("167"), // Brick red
+			Success:     lipgloss.Color("114"), // Light green
+			Warning:     lipgloss.Color("178"), // Gold
+			Border:      lipgloss.Color("58"),  // Dark olive
+			ProgressBar: lipgloss.Color("71"),  // Leaf green
+		},
+		Light: ColorScheme{
+			Primary:     lipgloss.Color("28"),  // Dark green
+			Secondary:   lipgloss.Color("64"),  // Olive
+			Background:  lipgloss.Color("255"), // White
+			Foreground:  lipgloss.Color("235"), // Dark gray
+			Accent:      lipgloss.Color("130"), // Brown
+			Muted:       lipgloss.Color("101"), // Moss
+			Error:       lipgloss.Color("124"), // Dark red
+			Success:     lipgloss.Color("28"),  // Dark green
+			Warning:     lipgloss.Color("136"), // Dark gold
+			Border:      lipgloss.Color("144"), // Khaki
+			ProgressBar: lipgloss.Color("28"),  // Dark green
+		},
+	},
+	{
+		Name:        "sunset",
+		Description: "oranges and reds",
+		Dark: ColorScheme{
+			Primary:     lipgloss.Color("208"), // Orange
+			Secondary:   lipgloss.Color("216"), // Peach
+			Background:  lipgloss.Color("52"),  // Dark maroon
+			Foreground:  lipgloss.Color("230"), // Cream
+			Accent:      lipgloss.Color("205"), // Hot pink
+			Muted:       lipgloss.Color("138"), // Dusty rose
+			Error:       lipgloss.Color("196"), // Red
+			Success:     lipgloss.Color("150"), // Pale green
+			Warning:     lipgloss.Color("220"), // Yellow
+			Border:      lipgloss.Color("95"),  // Mauve
+			ProgressBar: lipgloss.Color("208"), // Orange
+		},
+		Light: ColorScheme{
+			Primary:     lipgloss.Color("166"), // Dark orange
+			Secondary:   lipgloss.Color("131"), // Rust
+			Background:  lipgloss.Color("230"), // Cream
+			Foreground:  lipgloss.Color("52"),  // Dark maroon
+			Accent:      lipgloss.Color("161"), // Magenta
+			Muted:       lipgloss.Color("138"), // Dusty rose
+			Error:       lipgloss.Color("124"), // Dark red
+			Success:     lipgloss.Color("64"),  // Olive green
+			Warning:     lipgloss.Color("172"), // Amber
+			Border:      lipgloss.Color("181"), // Light rose
+			ProgressBar: lipgloss.Color("166"), // Dark orange
+		},
+	},
+	{
+		Name:        "mono",
+		Description: "grays only, for decks that bring their own colors",
+		Dark: ColorScheme{
+			Primary:     lipgloss.Color("255"), // White
+			Secondary:   lipgloss.Color("250"), // Silver
+			Background:  lipgloss.Color("234"), // Near black
+			Foreground:  lipgloss.Color("255"), // White
+			Accent:      lipgloss.Color("252"), // Light gray
+			Muted:       lipgloss.Color("244"), // Gray
+			Error:       lipgloss.Color("255"), // White
+			Success:     lipgloss.Color("250"), // Silver
+			Warning:     lipgloss.Color("252"), // Light gray
+			Border:      lipgloss.Color("240"), // Dark gray
+			ProgressBar: lipgloss.Color("250"), // Silver
+		},
+		Light: ColorScheme{
+			Primary:     lipgloss.Color("232"), // Black
+			Secondary:   lipgloss.Color("238"), // Dark gray
+			Background:  lipgloss.Color("255"), // White
+			Foreground:  lipgloss.Color("232"), // Black
+			Accent:      lipgloss.Color("236"), // Charcoal
+			Muted:       lipgloss.Color("243"), // Gray
+			Error:       lipgloss.Color("232"), // Black
+			Success:     lipgloss.Color("238"), // Dark gray
+			Warning:     lipgloss.Color("236"), // Charcoal
+			Border:      lipgloss.Color("248"), // Light gray
+			ProgressBar: lipgloss.Color("238"), // Dark gray
+		},
+	},
+	{
+		Name:        "high-contrast",
+		Description: "bright, saturated colors for projectors and low vision",
+		Dark: ColorScheme{
+			Primary:     lipgloss.Color("15"),  // Bright white
+			Secondary:   lipgloss.Color("14"),  // Bright cyan
+			Background:  lipgloss.Color("16"),  // Black
+			Foreground:  lipgloss.Color("15"),  // Bright white
+			Accent:      lipgloss.Color("11"),  // Bright yellow
+			Muted:       lipgloss.Color("250"), // Silver
+			Error:       lipgloss.Color("9"),   // Bright red
+			Success:     lipgloss.Color("10"),  // Bright green
+			Warning:     lipgloss.Color("11"),  // Bright yellow
+			Border:      lipgloss.Color("15"),  // Bright white
+			ProgressBar: lipgloss.Color("11"),  // Bright yellow
+		},
+		Light: ColorScheme{
+			Primary:     lipgloss.Color("16"),  // Black
+			Secondary:   lipgloss.Color("18"),  // Navy
+			Background:  lipgloss.Color("231"), // White
+			Foreground:  lipgloss.Color("16"),  // Black
+			Accent:      lipgloss.Color("90"),  // Dark magenta
+			Muted:       lipgloss.Color("238"), // Dark gray
+			Error:       lipgloss.Color("124"), // Dark red
+			Success:     lipgloss.Color("22"),  // Dark green
+			Warning:     lipgloss.Color("94"),  // Dark orange
+			Border:      lipgloss.Color("16"),  // Black
+			ProgressBar: lipgloss.Color("18"),  // Navy
+		},
+	},
+}
+
+// FindPalette returns a bundled palette by name
+func FindPalette(name string) (Palette, bool) {
+	index := slices.IndexFunc(Palettes, func(palette Palette) bool { return palette.Name == name })
+	if index < 0 {
+		return Palette{}, false
+	}
+	return Palettes[index], true
+}
+
+// PaletteNames returns the names of the bundled palettes, followed by the
+// ones custom defines that are not bundled, sorted
+func PaletteNames(custom map[string]models.ColorsConfig) []string {
+	names := make([]string, 0, len(Palettes)+len(custom))
+	for _, palette := range Palettes {
+		names = append(names, palette.Name)
+	}
+
+	var added []string
+	for name := range custom {
+		if _, ok := FindPalette(name); !ok {
+			added = append(added, name)
+		}
+	}
+	slices.Sort(added)
+
+	return append(names, added...)
+}
+
+func (s *ColorScheme) fields() []*lipgloss.Color {
+	return []*lipgloss.Color{
+		&s.Primary, &s.Secondary, &s.Background, &s.Foreground, &s.Accent, &s.Muted,
+		&s.Error, &s.Success, &s.Warning, &s.Border, &s.ProgressBar,
+	}
+}
+
+// ApplyColors replaces the colors of scheme that colors sets. Colors that
+// cannot be read are reported by name and leave the scheme color as is.
+func ApplyColors(scheme ColorScheme, colors models.ColorsConfig) (ColorScheme, error) {
+	var errs []error
+
+	fields := scheme.fields()
+	for i, value := range colors.Values() {
+		if value == "" {
+			continue
+		}
+		color, err := ParseColor(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s color: %w", models.ColorNames[i], err))
+			continue
+		}
+		*fields[i] = color
+	}
+
+	return scheme, errors.Join(errs...)
+}
+
+// ValidateColors reports a palette that is neither bundled nor defined,
+// and colors of the config or its palettes that cannot be read
+func ValidateColors(config models.ThemeConfig) error {
+	names := PaletteNames(config.Palettes)
+	if config.Palette != "" && !slices.Contains(names, config.Palette) {
+		return fmt.Errorf("unknown palette %q, use one of %s", config.Palette, strings.Join(names, ", "))
+	}
+
+	if _, err := ApplyColors(ColorScheme{}, config.Colors); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if colors, ok := config.Palettes[name]; ok {
+			if _, err := ApplyColors(ColorScheme{}, colors); err != nil {
+				return fmt.Errorf("palette %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package theme
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/muesli/termenv"
+)
+
+// * Styles bundled with slate, on top of the ones glamour ships
+//
+//go:embed styles/*.json
+var styleFiles embed.FS
+
+// * Glamour styles bundled with slate
+const (
+	GlamourHighContrast      = "high-contrast"
+	GlamourHighContrastLight = "high-contrast-light"
+	GlamourProjector         = "projector"
+)
+
+// * StyleInfo describes a glamour style slate knows by name
+type StyleInfo struct {
+	Name        string
+	Description string
+	// Light styles are made for light terminal backgrounds
+	Light bool
+}
+
+// Styles lists the glamour styles that can be given by name, in the order
+// they are shown to users
+var Styles = []StyleInfo{
+	{Name: styles.AutoStyle, Description: "dark or light, following the terminal background"},
+	{Name: GlamourDark, Description: "light text on dark terminals"},
+	{Name: GlamourLight, Description: "dark text on light terminals", Light: true},
+	{Name: GlamourDracula, Description: "the Dracula color scheme"},
+	{Name: styles.TokyoNightStyle, Description: "the Tokyo Night color scheme"},
+	{Name: GlamourPink, Description: "pink headings and accents"},
+	{Name: GlamourHighContrast, Description: "white and bright yellow on black, for low vision and bright rooms"},
+	{Name: GlamourHighContrastLight, Description: "black and navy on white, for low vision and bright rooms", Light: true},
+	{Name: GlamourProjector, Description: "dark, saturated colors and more spacing, for washed out projectors", Light: true},
+	{Name: styles.AsciiStyle, Description: "no colors or special characters"},
+	{Name: styles.NoTTYStyle, Description: "no colors, for output that is not a terminal"},
+}
+
+// StyleNames returns the names of the glamour styles slate knows
+func StyleNames() []string {
+	names := make([]string, len(Styles))
+	for i, style := range Styles {
+		names[i] = style.Name
+	}
+	return names
+}
+
+// IsStyleName reports whether name is a style slate knows, rather than the
+// path to a JSON style file
+func IsStyleName(name string) bool {
+	return slices.ContainsFunc(Styles, func(style StyleInfo) bool { return style.Name == name })
+}
+
+// IsLightStyle reports whether a style is made for light backgrounds
+func IsLightStyle(name string) bool {
+	return slices.ContainsFunc(Styles, func(style StyleInfo) bool { return style.Name == name && style.Light })
+}
+
+// isStyleFile reports whether name is meant as the path to a JSON style
+// rather than a style name, so typos in names are reported as such
+func isStyleFile(name string) bool {
+	return strings.ContainsAny(name, `/\`) || strings.EqualFold(filepath.Ext(name), ".json")
+}
+
+// ResolveStylePath makes a relative path to a JSON style relative to dir,
+// the directory of the deck or configuration file that names it. Style names,
+// known or not, and absolute paths are returned as they are.
+func ResolveStylePath(name, dir string) string {
+	if name == "" || !isStyleFile(name) || filepath.IsAbs(name) || dir == "" {
+		return name
+	}
+	return filepath.Join(dir, filepath.FromSlash(name))
+}
+
+// ValidateStyle reports a style that cannot be loaded. Style names are
+// known to be good, so only style files are read.
+func ValidateStyle(name string) error {
+	if name == "" || IsStyleName(name) {
+		return nil
+	}
+	_, err := LoadStyle(name)
+	return err
+}
+
+// LoadStyle reads a glamour style by name, or from a JSON file. Fields the
+// style format does not have are reported, so typos do not go unnoticed.
+func LoadStyle(name string) (ansi.StyleConfig, error) {
+	if name == styles.AutoStyle {
+		if termenv.HasDarkBackground() {
+			return styles.DarkStyleConfig, nil
+		}
+		return styles.LightStyleConfig, nil
+	}
+
+	if style, ok := styles.DefaultStyles[name]; ok {
+		return *style, nil
+	}
+
+	if content, err := styleFiles.ReadFile("styles/" + name + ".json"); err == nil {
+		return parseStyle(name, content)
+	}
+
+	// ? A name without a path or .json extension was meant as a style name
+	if !isStyleFile(name) {
+		return ansi.StyleConfig{}, fmt.Errorf("unknown glamour style %q, use one of %s or a path to a JSON style",
+			name, strings.Join(StyleNames(), ", "))
+	}
+
+	content, err := os.ReadFile(filepath.Clean(name))
+	if err != nil {
+		return ansi.StyleConfig{}, fmt.Errorf("failed to read glamour style: %w", err)
+	}
+
+	return parseStyle(name, content)
+}
+
+func parseStyle(name string, content []byte) (ansi.StyleConfig, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	var style ansi.StyleConfig
+	if err := decoder.Decode(&style); err != nil {
+		return ansi.StyleConfig{}, fmt.Errorf("invalid glamour style %s: %s", name, describeJSONError(content, err))
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return ansi.StyleConfig{}, fmt.Errorf("invalid glamour style %s: unexpected content after the style", name)
+	}
+
+	return style, nil
+}
+
+// describeJSONError adds the line to JSON errors that only give an offset
+func describeJSONError(content []byte, err error) string {
+	var offset int64 = -1
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+
+	message := strings.TrimPrefix(err.Error(), "json: ")
+	if offset < 0 {
+		return message
+	}
+
+	line := 1 + bytes.Count(content[:min(offset, int64(len(content)))], []byte("\n"))
+	return fmt.Sprintf("line %d: %s", line, message)
+}
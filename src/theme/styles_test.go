@@ -0,0 +1,89 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveStylePath(t *testing.T) {
+	dir := filepath.Join("home", "talks")
+
+	tests := []struct {
+		name     string
+		style    string
+		expected string
+	}{
+		{"Known name", "dracula", "dracula"},
+		{"Misspelled name", "drak", "drak"},
+		{"Relative file", "talk.json", filepath.Join(dir, "talk.json")},
+		{"Relative path", "styles/talk", filepath.Join(dir, "styles", "talk")},
+		{"Empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveStylePath(tt.style, dir); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateStyleErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Expected to write %s, got %v", name, err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name     string
+		style    string
+		expected []string
+	}{
+		{"Misspelled name", ResolveStylePath("drak", dir), []string{`unknown glamour style "drak"`, "dracula"}},
+		{"Missing file", ResolveStylePath("missing.json", dir), []string{"failed to read glamour style"}},
+		{"Bad JSON", write("bad.json", "{\n  \"document\": {\n    \"color\": \"252\",\n  }\n}"), []string{"invalid glamour style", "line 4"}},
+		{"Unknown field", write("typo.json", `{"documnet": {}}`), []string{"invalid glamour style", `unknown field "documnet"`}},
+		{"Wrong type", write("type.json", "{\n  \"document\": {\n    \"margin\": \"2\"\n  }\n}"), []string{"line 3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStyle(tt.style)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			for _, part := range tt.expected {
+				if !strings.Contains(err.Error(), part) {
+					t.Errorf("Expected error to contain %q, got %q", part, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadStyle(t *testing.T) {
+	for _, name := range StyleNames() {
+		if _, err := LoadStyle(name); err != nil {
+			t.Errorf("Expected style %s to load, got %v", name, err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "talk.json")
+	if err := os.WriteFile(path, []byte(`{"document": {"color": "252"}}`), 0600); err != nil {
+		t.Fatalf("Expected to write the style, got %v", err)
+	}
+	style, err := LoadStyle(path)
+	if err != nil {
+		t.Fatalf("Expected the style file to load, got %v", err)
+	}
+	if style.Document.Color == nil || *style.Document.Color != "252" {
+		t.Errorf("Expected document color 252, got %v", style.Document.Color)
+	}
+}
@@ -1,8 +1,11 @@
 package theme
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/Kosha-Nirman/slate/src/models"
@@ -49,6 +52,29 @@ type Manager struct {
 	colorScheme ColorScheme
 }
 
+// DeckTheme puts the style, palette and colors the deck picks in its front
+// matter on top of the configured theme, with style files relative to the deck
+func DeckTheme(configured models.ThemeConfig, presentation *models.Presentation) (models.ThemeConfig, error) {
+	result := configured
+
+	if presentation.GlamourStyle != "" {
+		result.GlamourStyle = ResolveStylePath(presentation.GlamourStyle, filepath.Dir(presentation.FilePath))
+		if err := ValidateStyle(result.GlamourStyle); err != nil {
+			return configured, fmt.Errorf("invalid glamourStyle in front matter: %w", err)
+		}
+	}
+
+	if presentation.Palette != "" {
+		result.Palette = presentation.Palette
+	}
+	result.Colors.Merge(presentation.Colors)
+	if err := ValidateColors(result); err != nil {
+		return configured, fmt.Errorf("invalid colors in front matter: %w", err)
+	}
+
+	return result, nil
+}
+
 func NewManager(config *models.ThemeConfig) *Manager {
 	manager := &Manager{
 		config: config,
@@ -109,36 +135,26 @@ func (m *Manager) detectDarkMode() bool {
 	}
 }
 
+// createColorScheme takes the palette the config picks, in the variant for
+// the terminal background, with the configured colors on top
 func (m *Manager) createColorScheme() ColorScheme {
-	if m.isDark {
-		return ColorScheme{
-			Primary:     lipgloss.Color("63"),  // Purple
-			Secondary:   lipgloss.Color("99"),  // Light purple
-			Background:  lipgloss.Color("235"), // Dark gray
-			Foreground:  lipgloss.Color("255"), // White
-			Accent:      lipgloss.Color("212"), // Pink
-			Muted:       lipgloss.Color("241"), // Gray
-			Error:       lipgloss.Color("196"), // Red
-			Success:     lipgloss.Color("46"),  // Green
-			Warning:     lipgloss.Color("214"), // Orange
-			Border:      lipgloss.Color("240"), // Dark border
-			ProgressBar: lipgloss.Color("63"),  // Purple
-		}
+	name := m.GetPalette()
+	palette, ok := FindPalette(name)
+	if !ok {
+		palette, _ = FindPalette(DefaultPalette)
 	}
 
-	return ColorScheme{
-		Primary:     lipgloss.Color("63"),  // Purple
-		Secondary:   lipgloss.Color("99"),  // Light purple
-		Background:  lipgloss.Color("255"), // White
-		Foreground:  lipgloss.Color("235"), // Dark gray
-		Accent:      lipgloss.Color("212"), // Pink
-		Muted:       lipgloss.Color("246"), // Light gray
-		Error:       lipgloss.Color("160"), // Dark red
-		Success:     lipgloss.Color("28"),  // Dark green
-		Warning:     lipgloss.Color("166"), // Dark orange
-		Border:      lipgloss.Color("246"), // Light border
-		ProgressBar: lipgloss.Color("63"),  // Purple
+	scheme := palette.Light
+	if m.isDark {
+		scheme = palette.Dark
 	}
+
+	// ? Colors were checked when the config and deck were loaded, any left
+	// that cannot be read keep the palette color
+	scheme, _ = ApplyColors(scheme, m.config.Palettes[name])
+	scheme, _ = ApplyColors(scheme, m.config.Colors)
+
+	return scheme
 }
 
 func (m *Manager) IsDark() bool {
@@ -149,6 +165,38 @@ func (m *Manager) GetColorScheme() ColorScheme {
 	return m.colorScheme
 }
 
+// GetPalette returns the name of the palette the chrome is drawn in
+func (m *Manager) GetPalette() string {
+	if m.config.Palette == "" {
+		return DefaultPalette
+	}
+	return m.config.Palette
+}
+
+// SetPalette draws the chrome in another palette, bundled or defined in
+// the config
+func (m *Manager) SetPalette(name string) error {
+	name = strings.ToLower(name)
+	if !slices.Contains(PaletteNames(m.config.Palettes), name) {
+		return fmt.Errorf("unknown palette %q", name)
+	}
+
+	m.config.Palette = name
+	m.colorScheme = m.createColorScheme()
+	return nil
+}
+
+// NextPalette switches to the palette after the current one, wrapping
+// around, and returns its name
+func (m *Manager) NextPalette() string {
+	names := PaletteNames(m.config.Palettes)
+	next := names[(slices.Index(names, m.GetPalette())+1)%len(names)]
+
+	m.config.Palette = next
+	m.colorScheme = m.createColorScheme()
+	return next
+}
+
 func (m *Manager) GetGlamourStyle() string {
 	return m.config.GlamourStyle
 }
@@ -203,6 +251,10 @@ func (m *Manager) ErrorStyle() lipgloss.Style {
 	return lipgloss.NewStyle().Bold(true).Foreground(m.colorScheme.Error)
 }
 
+func (m *Manager) WarningStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Bold(true).Foreground(m.colorScheme.Warning)
+}
+
 func (m *Manager) BorderStyle() lipgloss.Style {
 	return lipgloss.NewStyle().BorderStyle(lipgloss.RoundedBorder()).BorderForeground(m.colorScheme.Border)
 }
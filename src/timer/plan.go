@@ -0,0 +1,172 @@
+package timer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kosha-Nirman/slate/src/models"
+)
+
+// * Level tells how close a talk is to running out of time
+type Level int
+
+const (
+	LevelNormal Level = iota
+	LevelWarning
+	LevelCritical
+	LevelOver
+)
+
+// * Plan is the time a talk has and how it is split across the slides
+type Plan struct {
+	// Duration is the length of the whole talk
+	Duration time.Duration
+	// starts holds when each slide is planned to begin, plus the planned end
+	starts []time.Duration
+}
+
+// NewPlan splits duration across slides. Slides with a budget of their own
+// keep it and the rest share what is left equally. Without a duration the
+// talk lasts as long as its budgets add up to.
+func NewPlan(duration time.Duration, budgets []time.Duration) Plan {
+	var budgeted time.Duration
+	unbudgeted := 0
+	for _, budget := range budgets {
+		if budget > 0 {
+			budgeted += budget
+		} else {
+			unbudgeted++
+		}
+	}
+
+	if duration <= 0 {
+		duration = budgeted
+	}
+
+	var share time.Duration
+	if unbudgeted > 0 && duration > budgeted {
+		share = (duration - budgeted) / time.Duration(unbudgeted)
+	}
+
+	starts := make([]time.Duration, 0, len(budgets)+1)
+	var at time.Duration
+	for _, budget := range budgets {
+		starts = append(starts, at)
+		if budget > 0 {
+			at += budget
+		} else {
+			at += share
+		}
+	}
+	starts = append(starts, at)
+
+	return Plan{Duration: duration, starts: starts}
+}
+
+// PlanFor builds the plan of a deck from its duration and the @time budgets
+// of its slides, ignoring values that are not durations
+func PlanFor(deck *models.Presentation) Plan {
+	duration, _ := ParseDuration(deck.Duration)
+
+	budgets := make([]time.Duration, len(deck.Slides))
+	for i, slide := range deck.Slides {
+		budgets[i], _ = ParseDuration(slide.Metadata.Time)
+	}
+
+	return NewPlan(duration, budgets)
+}
+
+// ParseDuration reads a talk or slide length such as 20m or 1m30s. An empty
+// value is no length at all.
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q, use a length such as 90s or 20m", value)
+	}
+	return d, nil
+}
+
+// * planJSON is how a plan is sent to presenter consoles
+type planJSON struct {
+	Duration time.Duration   `json:"duration"`
+	Starts   []time.Duration `json:"starts"`
+}
+
+func (p Plan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(planJSON{Duration: p.Duration, Starts: p.starts})
+}
+
+func (p *Plan) UnmarshalJSON(data []byte) error {
+	var decoded planJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	p.Duration, p.starts = decoded.Duration, decoded.Starts
+	return nil
+}
+
+// IsZero reports whether the talk has no time set
+func (p Plan) IsZero() bool {
+	return p.Duration <= 0
+}
+
+// Remaining returns the time left, negative once the talk runs over
+func (p Plan) Remaining(elapsed time.Duration) time.Duration {
+	return p.Duration - elapsed
+}
+
+// Pace compares the elapsed time with the time planned for the current
+// slide. It is positive when the talk is behind by that much, negative when
+// ahead and zero while on schedule.
+func (p Plan) Pace(elapsed time.Duration, slide int) time.Duration {
+	if p.IsZero() || slide < 0 || slide >= len(p.starts)-1 {
+		return 0
+	}
+
+	start, end := p.starts[slide], p.starts[slide+1]
+	switch {
+	case elapsed > end:
+		return elapsed - end
+	case elapsed < start:
+		return elapsed - start
+	}
+	return 0
+}
+
+// Level returns how close the talk is to its end, given how much remaining
+// time counts as a warning and as critical
+func (p Plan) Level(elapsed, warning, critical time.Duration) Level {
+	if p.IsZero() {
+		return LevelNormal
+	}
+
+	remaining := p.Remaining(elapsed)
+	switch {
+	case remaining < 0:
+		return LevelOver
+	case remaining <= critical:
+		return LevelCritical
+	case remaining <= warning:
+		return LevelWarning
+	}
+	return LevelNormal
+}
+
+// DescribePace puts a pace from Pace into words
+func DescribePace(pace time.Duration) string {
+	// ? Differences under a second are rounding, not pacing
+	switch {
+	case pace >= time.Second:
+		return Format(pace) + " behind"
+	case pace <= -time.Second:
+		return Format(pace) + " ahead"
+	}
+	return "on pace"
+}
@@ -0,0 +1,75 @@
+package timer
+
+import (
+	"fmt"
+	"time"
+)
+
+// * Timer measures how long a talk has been running and can be paused
+type Timer struct {
+	started time.Time
+	// pausedAt is when the timer was paused, zero while it runs
+	pausedAt time.Time
+	now      func() time.Time
+}
+
+func New() *Timer {
+	t := &Timer{now: time.Now}
+	t.started = t.now()
+	return t
+}
+
+// Elapsed returns the running time, not counting time spent paused
+func (t *Timer) Elapsed() time.Duration {
+	end := t.now()
+	if t.Paused() {
+		end = t.pausedAt
+	}
+	return end.Sub(t.started)
+}
+
+func (t *Timer) Paused() bool {
+	return !t.pausedAt.IsZero()
+}
+
+// Toggle pauses a running timer or resumes a paused one, and reports
+// whether it is now paused
+func (t *Timer) Toggle() bool {
+	now := t.now()
+	if t.Paused() {
+		// ? Move the start forward by the pause so it is not counted
+		t.started = t.started.Add(now.Sub(t.pausedAt))
+		t.pausedAt = time.Time{}
+		return false
+	}
+
+	t.pausedAt = now
+	return true
+}
+
+// Reset sets the elapsed time back to zero, keeping the timer paused or
+// running as it was
+func (t *Timer) Reset() {
+	now := t.now()
+	t.started = now
+	if t.Paused() {
+		t.pausedAt = now
+	}
+}
+
+// Format writes a duration as m:ss, or h:mm:ss from an hour on
+func Format(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	d = d.Round(time.Second)
+
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
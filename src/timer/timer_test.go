@@ -0,0 +1,132 @@
+package timer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimerPause(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	timer := &Timer{started: now, now: func() time.Time { return now }}
+
+	now = now.Add(time.Minute)
+	if !timer.Toggle() {
+		t.Fatal("Expected timer to be paused")
+	}
+
+	now = now.Add(10 * time.Minute)
+	if elapsed := timer.Elapsed(); elapsed != time.Minute {
+		t.Errorf("Expected paused time not to count, got %v", elapsed)
+	}
+
+	timer.Toggle()
+	now = now.Add(30 * time.Second)
+	if elapsed := timer.Elapsed(); elapsed != 90*time.Second {
+		t.Errorf("Expected 1m30s after resuming, got %v", elapsed)
+	}
+
+	timer.Reset()
+	if elapsed := timer.Elapsed(); elapsed != 0 {
+		t.Errorf("Expected reset to clear the elapsed time, got %v", elapsed)
+	}
+}
+
+func TestPlanPace(t *testing.T) {
+	// ? 10 minutes over four slides, one of which takes 4 minutes
+	plan := NewPlan(10*time.Minute, []time.Duration{0, 4 * time.Minute, 0, 0})
+
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		slide    int
+		expected time.Duration
+	}{
+		{"On first slide in time", time.Minute, 0, 0},
+		{"Lingering on first slide", 3 * time.Minute, 0, time.Minute},
+		{"Inside budgeted slide", 5 * time.Minute, 1, 0},
+		{"Reached last slide early", 6 * time.Minute, 3, -2 * time.Minute},
+		{"Over time", 11 * time.Minute, 3, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if pace := plan.Pace(tt.elapsed, tt.slide); pace != tt.expected {
+				t.Errorf("Expected pace %v, got %v", tt.expected, pace)
+			}
+		})
+	}
+}
+
+func TestPlanJSON(t *testing.T) {
+	plan := NewPlan(10*time.Minute, []time.Duration{0, 4 * time.Minute, 0, 0})
+
+	data, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var decoded Plan
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if decoded.Duration != plan.Duration {
+		t.Errorf("Expected duration %v, got %v", plan.Duration, decoded.Duration)
+	}
+	for slide := range 4 {
+		elapsed := 3 * time.Minute
+		if got, want := decoded.Pace(elapsed, slide), plan.Pace(elapsed, slide); got != want {
+			t.Errorf("Expected pace %v on slide %d, got %v", want, slide, got)
+		}
+	}
+}
+
+func TestPlanWithoutDuration(t *testing.T) {
+	plan := NewPlan(0, []time.Duration{time.Minute, 0, 30 * time.Second})
+
+	if plan.Duration != 90*time.Second {
+		t.Errorf("Expected budgets to add up to 1m30s, got %v", plan.Duration)
+	}
+	if NewPlan(0, []time.Duration{0, 0}).IsZero() != true {
+		t.Error("Expected a plan without duration or budgets to be zero")
+	}
+}
+
+func TestPlanLevel(t *testing.T) {
+	plan := NewPlan(20*time.Minute, nil)
+
+	tests := []struct {
+		elapsed  time.Duration
+		expected Level
+	}{
+		{10 * time.Minute, LevelNormal},
+		{16 * time.Minute, LevelWarning},
+		{19*time.Minute + 30*time.Second, LevelCritical},
+		{21 * time.Minute, LevelOver},
+	}
+
+	for _, tt := range tests {
+		if level := plan.Level(tt.elapsed, 5*time.Minute, time.Minute); level != tt.expected {
+			t.Errorf("Expected level %d at %v, got %d", tt.expected, tt.elapsed, level)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{0, "0:00"},
+		{90 * time.Second, "1:30"},
+		{-45 * time.Second, "0:45"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "1:02:03"},
+	}
+
+	for _, tt := range tests {
+		if got := Format(tt.duration); got != tt.expected {
+			t.Errorf("Expected %q for %v, got %q", tt.expected, tt.duration, got)
+		}
+	}
+}
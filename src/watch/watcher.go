@@ -0,0 +1,149 @@
+package watch
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	defaultInterval = 200 * time.Millisecond
+	defaultDebounce = 300 * time.Millisecond
+)
+
+// * fileState is the part of a stat result that signals a change
+type fileState struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// * Watcher polls a set of files and reports changes once they settle.
+// * Polling keeps it dependency free and works the same on every platform.
+type Watcher struct {
+	interval time.Duration
+	debounce time.Duration
+
+	mu     sync.Mutex
+	states map[string]fileState
+
+	events  chan struct{}
+	done    chan struct{}
+	closing sync.Once
+}
+
+func New(paths ...string) *Watcher {
+	w := &Watcher{
+		interval: defaultInterval,
+		debounce: defaultDebounce,
+		states:   make(map[string]fileState),
+		events:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	for _, path := range paths {
+		w.Add(path)
+	}
+
+	return w
+}
+
+func stat(path string) fileState {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}
+	}
+
+	return fileState{modTime: info.ModTime(), size: info.Size(), exists: true}
+}
+
+// Add starts watching another file, such as one included by the deck
+func (w *Watcher) Add(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.states[path]; !ok {
+		w.states[path] = stat(path)
+	}
+}
+
+// SetPaths replaces the watched files, such as when a reloaded deck includes
+// other files. Files watched before keep their state, so a change made while
+// reloading is still reported.
+func (w *Watcher) SetPaths(paths ...string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	states := make(map[string]fileState, len(paths))
+	for _, path := range paths {
+		if previous, ok := w.states[path]; ok {
+			states[path] = previous
+		} else {
+			states[path] = stat(path)
+		}
+	}
+	w.states = states
+}
+
+func (w *Watcher) Start() {
+	go w.loop()
+}
+
+func (w *Watcher) changed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	changed := false
+	for path, previous := range w.states {
+		current := stat(path)
+		if current != previous {
+			w.states[path] = current
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func (w *Watcher) loop() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	defer close(w.events)
+
+	var lastChange time.Time
+	pending := false
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case now := <-ticker.C:
+			if w.changed() {
+				lastChange = now
+				pending = true
+				continue
+			}
+
+			// ? Wait for editors to finish writing before reporting
+			if pending && now.Sub(lastChange) >= w.debounce {
+				pending = false
+				select {
+				case w.events <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// Events receives a value each time the watched files change and settle.
+// The channel is closed once a started watcher is closed.
+func (w *Watcher) Events() <-chan struct{} {
+	return w.events
+}
+
+func (w *Watcher) Close() {
+	w.closing.Do(func() {
+		close(w.done)
+	})
+}
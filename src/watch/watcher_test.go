@@ -0,0 +1,100 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestWatcher watches paths with short delays, so tests run quickly
+func newTestWatcher(t *testing.T, paths ...string) *Watcher {
+	t.Helper()
+
+	w := New(paths...)
+	w.interval = 10 * time.Millisecond
+	w.debounce = 20 * time.Millisecond
+	w.Start()
+	t.Cleanup(w.Close)
+
+	return w
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectEvent(t *testing.T, w *Watcher, expected bool) {
+	t.Helper()
+
+	select {
+	case <-w.Events():
+		if !expected {
+			t.Error("Expected no change event")
+		}
+	case <-time.After(300 * time.Millisecond):
+		if expected {
+			t.Error("Expected a change event, timed out")
+		}
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	deck := filepath.Join(dir, "slides.md")
+	style := filepath.Join(dir, "style.json")
+	image := filepath.Join(dir, "image.png")
+	writeFile(t, deck, "# One")
+	writeFile(t, style, "{}")
+	writeFile(t, image, "png")
+
+	w := newTestWatcher(t, deck)
+
+	t.Run("Watched file", func(t *testing.T) {
+		writeFile(t, deck, "# One\n\n---\n\n# Two")
+		expectEvent(t, w, true)
+	})
+
+	t.Run("Unwatched file", func(t *testing.T) {
+		writeFile(t, style, `{"document": {}}`)
+		expectEvent(t, w, false)
+	})
+
+	t.Run("Added file", func(t *testing.T) {
+		w.SetPaths(deck, style)
+		writeFile(t, style, `{"heading": {}}`)
+		expectEvent(t, w, true)
+	})
+
+	t.Run("Created file", func(t *testing.T) {
+		w.SetPaths(deck, style, filepath.Join(dir, "later.png"))
+		writeFile(t, filepath.Join(dir, "later.png"), "png")
+		expectEvent(t, w, true)
+	})
+
+	t.Run("Removed file", func(t *testing.T) {
+		w.SetPaths(deck, image)
+		writeFile(t, style, `{"paragraph": {}}`)
+		expectEvent(t, w, false)
+
+		writeFile(t, image, "another png")
+		expectEvent(t, w, true)
+	})
+}
+
+func TestWatcherClose(t *testing.T) {
+	w := newTestWatcher(t)
+	w.Close()
+
+	select {
+	case _, ok := <-w.Events():
+		if ok {
+			t.Error("Expected the events channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the events channel to close, timed out")
+	}
+}